@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/report"
+)
+
+const adviseUsage = `usage:
+  pitcalc advise <id> [<id>]        advise on saved calculations: how two
+                                    taxpayers should share the dependent
+                                    relief, and what SSB or other deductions
+                                    would save (see pitcalc history list);
+                                    give each by its ID or label`
+
+// runAdvise implements the advise subcommand and returns the exit code. The
+// taxpayers are saved calculations, given by their IDs or labels.
+func runAdvise(store *history.Store, lang i18n.Lang, args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintln(stderr, adviseUsage)
+		return 2
+	}
+
+	var (
+		inputs []pitcalc.CalculatePITInput
+		names  []string
+	)
+	for _, arg := range args {
+		e, ok := historyEntry(store, lang, arg, stderr)
+		if !ok {
+			return 1
+		}
+		inputs = append(inputs, e.Input)
+		name := e.Label
+		if name == "" {
+			name = fmt.Sprintf("#%d", e.ID)
+		}
+		names = append(names, name)
+	}
+	a, err := pitcalc.Advise(inputs...)
+	if err != nil {
+		fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_err_calc", report.ErrorMessage(lang, err)))
+		return 1
+	}
+
+	fmt.Fprintln(stdout, i18n.T(lang, "advice_title"))
+	fmt.Fprintf(stdout, "%s: %s\n\n", i18n.T(lang, "advice_current"), currencyFormat(lang, a.CurrentTax))
+	if len(inputs) > 1 {
+		headers, rows := report.AdviceTable(lang, a, names)
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, row := range append([][]string{headers}, rows...) {
+			fmt.Fprintln(tw, strings.TrimRight(strings.Join(row, "\t"), "\t"))
+		}
+		tw.Flush()
+		fmt.Fprintln(stdout)
+	}
+	for _, note := range report.AdviceNotes(lang, a, names) {
+		fmt.Fprintln(stdout, "• "+note)
+	}
+	return 0
+}
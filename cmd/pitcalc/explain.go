@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/report"
+)
+
+// printExplanation writes the steps of a calculation as a table of what was
+// worked out, how, and the result.
+func printExplanation(w io.Writer, lang i18n.Lang, c *pitcalc.CalculatePITOutput) {
+	fmt.Fprintln(w, i18n.T(lang, "explain_title"))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, s := range report.Explain(lang, c) {
+		fmt.Fprintf(tw, "%s.\t%s\t%s\t%s\n", i18n.Digits(lang, fmt.Sprint(i+1)), s.Label, s.Working, s.Amount)
+	}
+	tw.Flush()
+}
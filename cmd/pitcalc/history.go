@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/report"
+)
+
+const historyUsage = `usage:
+  pitcalc history list              list saved calculations
+  pitcalc history show <id>         print a saved calculation
+  pitcalc history explain <id>      show how a saved calculation was worked out
+  pitcalc history export <id> <file>
+                                    write a saved calculation to a report file;
+                                    the format is taken from the extension
+
+A saved calculation is given by its ID or its label.`
+
+// runHistory implements the history subcommand and returns the exit code.
+func runHistory(store *history.Store, lang i18n.Lang, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, historyUsage)
+		return 2
+	}
+
+	entry := func(arg string) (history.Entry, bool) {
+		return historyEntry(store, lang, arg, stderr)
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		entries, err := store.List()
+		if err != nil {
+			fmt.Fprintf(stderr, "❌ %v\n", err)
+			return 1
+		}
+		if len(entries) == 0 {
+			fmt.Fprintln(stdout, i18n.T(lang, "history_empty"))
+			return 0
+		}
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, i18n.T(lang, "cli_history_header"))
+		for _, e := range entries {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n",
+				e.ID, e.Timestamp.Local().Format("2006-01-02 15:04"),
+				currencyFormat(lang, e.Output.GrossIncome), currencyFormat(lang, e.Output.TotalTax),
+				e.RuleSet, e.Label)
+		}
+		tw.Flush()
+		return 0
+
+	case args[0] == "show" && len(args) == 2:
+		e, ok := entry(args[1])
+		if !ok {
+			return 1
+		}
+		fmt.Fprintf(stdout, "#%d %s %s\n", e.ID, e.Timestamp.Local().Format("2006-01-02 15:04"), e.Label)
+		fmt.Fprintf(stdout, "%s: %s\n", i18n.T(lang, "cli_rules"), e.RuleSet)
+		fmt.Fprintln(stdout)
+		fmt.Fprint(stdout, report.PlainText(e.Input, e.Output))
+		return 0
+
+	case args[0] == "explain" && len(args) == 2:
+		e, ok := entry(args[1])
+		if !ok {
+			return 1
+		}
+		out := e.Output
+		if len(out.Explanation) == 0 {
+			// Saved before calculations were traced; work it out again.
+			var err error
+			if out, err = pitcalc.CalculatePIT(e.Input); err != nil {
+				fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_err_calc", report.ErrorMessage(lang, err)))
+				return 1
+			}
+		}
+		printExplanation(stdout, lang, out)
+		return 0
+
+	case args[0] == "export" && len(args) == 3:
+		e, ok := entry(args[1])
+		if !ok {
+			return 1
+		}
+		format, err := report.ParseFormat(filepath.Ext(args[2]))
+		if err != nil {
+			fmt.Fprintf(stderr, "❌ %v\n", err)
+			return 2
+		}
+		if err := report.WriteFile(args[2], format, lang, e.Input, e.Output); err != nil {
+			fmt.Fprintf(stderr, "❌ %s%v\n", i18n.T(lang, "err_export"), err)
+			return 1
+		}
+		fmt.Fprintln(stdout, i18n.T(lang, "success_export")+args[2])
+		return 0
+	}
+
+	fmt.Fprintln(stderr, historyUsage)
+	return 2
+}
+
+// historyEntry looks up the saved calculation with the ID or label given as
+// an argument, reporting to stderr when there is none or the label is given
+// to more than one. Labels match regardless of case.
+func historyEntry(store *history.Store, lang i18n.Lang, arg string, stderr io.Writer) (history.Entry, bool) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		entries, err := store.List()
+		if err != nil {
+			fmt.Fprintf(stderr, "❌ %v\n", err)
+			return history.Entry{}, false
+		}
+		var found []history.Entry
+		var ids []string
+		for _, e := range entries {
+			if strings.EqualFold(strings.TrimSpace(e.Label), strings.TrimSpace(arg)) {
+				found = append(found, e)
+				ids = append(ids, fmt.Sprintf("#%d", e.ID))
+			}
+		}
+		switch len(found) {
+		case 0:
+			fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_history_unknown", arg))
+			return history.Entry{}, false
+		case 1:
+			return found[0], true
+		}
+		fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_history_ambiguous", arg, strings.Join(ids, ", ")))
+		return history.Entry{}, false
+	}
+	e, err := store.Get(id)
+	if errors.Is(err, history.ErrNotFound) {
+		fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_history_not_found", id))
+		return history.Entry{}, false
+	} else if err != nil {
+		fmt.Fprintf(stderr, "❌ %v\n", err)
+		return history.Entry{}, false
+	}
+	return e, true
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/report"
+)
+
+const householdUsage = `usage:
+  pitcalc household [flags] <id> <id>
+                                    work out a couple's tax from their saved
+                                    calculations, each dependent claimed once;
+                                    give each by its ID or label`
+
+// runHousehold implements the household subcommand and returns the exit
+// code. The spouses are saved calculations, given by their IDs or labels.
+func runHousehold(store *history.Store, lang i18n.Lang, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("household", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, householdUsage)
+		fs.PrintDefaults()
+	}
+	var in pitcalc.HouseholdInput
+	fs.Int64Var(&in.DependentParents, "parents", 0,
+		"dependent parents of the household (default: those the saved calculations list)")
+	fs.Int64Var(&in.Childrens, "children", 0,
+		"children of the household (default: those the saved calculations list)")
+	best := fs.Bool("best", false, "claim the dependents in the way that pays the least tax")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var names []string
+	for i, arg := range fs.Args() {
+		e, ok := historyEntry(store, lang, arg, stderr)
+		if !ok {
+			return 1
+		}
+		name := e.Label
+		if name == "" {
+			name = fmt.Sprintf("#%d", e.ID)
+		}
+		names = append(names, name)
+		if claimed, _ := e.Input.ClaimDependents(); claimed.DependentSpouse != 0 {
+			fmt.Fprintln(stdout, "⚠️  "+i18n.Tf(lang, "household_spouse_dropped", name))
+			e.Input.DependentSpouse = 0
+			e.Input.Dependents = slices.DeleteFunc(slices.Clone(e.Input.Dependents), func(d pitcalc.Dependent) bool {
+				return d.Relationship == pitcalc.RelationSpouse
+			})
+		}
+		in.Spouses[i] = e.Input
+	}
+	// Dependents listed by name are told apart, so one listed by both
+	// spouses counts once; dependents claimed by number must be given.
+	if !set["parents"] || !set["children"] {
+		parents, children, ok := in.ListedDependents()
+		if !ok {
+			fmt.Fprintln(stderr, "❌ "+i18n.T(lang, "cli_err_household"))
+			return 2
+		}
+		if !set["parents"] {
+			in.DependentParents = parents
+		}
+		if !set["children"] {
+			in.Childrens = children
+		}
+	}
+	if *best {
+		var err error
+		if in, err = in.BestClaims(); err != nil {
+			fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_err_calc", report.ErrorMessage(lang, err)))
+			return 1
+		}
+	}
+	out, err := pitcalc.CalculateHousehold(in)
+	if err != nil {
+		fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_err_calc", report.ErrorMessage(lang, err)))
+		return 1
+	}
+
+	fmt.Fprintln(stdout, i18n.T(lang, "household_title"))
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\t%s\t%s\t%s\n", names[0], names[1], i18n.T(lang, "household_total"))
+	claims := []string{i18n.T(lang, "household_claims")}
+	for _, s := range in.Spouses {
+		s, _ = s.ClaimDependents()
+		claims = append(claims, i18n.Tf(lang, "advice_parents", s.DependentParents)+", "+
+			i18n.Tf(lang, "advice_children", s.Childrens))
+	}
+	fmt.Fprintln(tw, strings.Join(claims, "\t"))
+	for _, row := range report.HouseholdRows(lang, out) {
+		cells := []string{row.Label}
+		for _, v := range row.Values {
+			cells = append(cells, currencyFormat(lang, v))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	tw.Flush()
+	for i, s := range out.Spouses {
+		if len(s.Dependents) == 0 {
+			continue
+		}
+		fmt.Fprintf(stdout, "\n%s:\n", names[i])
+		for _, d := range report.Dependents(lang, s) {
+			fmt.Fprintf(stdout, "  %s: %s (%s)\n", d.Label, d.Decision, d.Relief)
+		}
+	}
+	if *best {
+		fmt.Fprintln(stdout, "\n"+i18n.T(lang, "household_best"))
+	}
+	return 0
+}
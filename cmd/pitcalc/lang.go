@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+)
+
+const langUsage = `usage:
+  pitcalc lang list                 list the available languages
+  pitcalc lang check [code|file]... report the strings languages do not
+                                    translate (all but English by default)`
+
+// runLang implements the lang subcommand and returns the exit code. check
+// exits with 1 when a language has untranslated or unknown strings, so it
+// can guard a catalogue file in CI.
+func runLang(lang i18n.Lang, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, langUsage)
+		return 2
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		total := len(i18n.IDs())
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, i18n.T(lang, "cli_lang_header"))
+		for _, l := range i18n.Languages() {
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\n", l, i18n.Name(l), total-len(i18n.Untranslated(l)), total)
+		}
+		tw.Flush()
+		return 0
+
+	case args[0] == "check":
+		var langs []i18n.Lang
+		for _, arg := range args[1:] {
+			if filepath.Ext(arg) == ".json" {
+				l, err := i18n.LoadFile(arg)
+				if err != nil {
+					fmt.Fprintf(stderr, "❌ %s: %v\n", arg, err)
+					return 1
+				}
+				langs = append(langs, l)
+				continue
+			}
+			l, ok := i18n.ParseLang(arg)
+			if !ok {
+				fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_err_lang", arg))
+				return 2
+			}
+			langs = append(langs, l)
+		}
+		if len(langs) == 0 {
+			langs = i18n.Languages()[1:]
+		}
+		code := 0
+		for _, l := range langs {
+			if !printCoverage(stdout, lang, l) {
+				code = 1
+			}
+		}
+		return code
+	}
+
+	fmt.Fprintln(stderr, langUsage)
+	return 2
+}
+
+// printCoverage lists the strings a language leaves in English and those it
+// has that English does not, reporting whether there were none.
+func printCoverage(w io.Writer, lang, l i18n.Lang) bool {
+	untranslated, unknown := i18n.Untranslated(l), i18n.Unknown(l)
+	heading := fmt.Sprintf("%s (%s): ", l, i18n.Name(l))
+	if len(untranslated) == 0 && len(unknown) == 0 {
+		fmt.Fprintln(w, heading+i18n.T(lang, "cli_lang_complete"))
+		return true
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if len(untranslated) > 0 {
+		fmt.Fprintln(tw, heading+i18n.Tf(lang, "cli_lang_untranslated", len(untranslated)))
+		for _, id := range untranslated {
+			fmt.Fprintf(tw, "  %s\t%q\n", id, i18n.T(i18n.EN, id))
+		}
+	}
+	if len(unknown) > 0 {
+		fmt.Fprintln(tw, heading+i18n.Tf(lang, "cli_lang_unknown", len(unknown)))
+		for _, id := range unknown {
+			fmt.Fprintf(tw, "  %s\n", id)
+		}
+	}
+	tw.Flush()
+	return false
+}
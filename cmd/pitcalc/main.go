@@ -2,105 +2,511 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
 	"github.com/myanmar-pit-calculator/pkg/pitcalc"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
+	"github.com/myanmar-pit-calculator/pkg/profile"
+	"github.com/myanmar-pit-calculator/pkg/report"
 )
 
+// useTraditionalUnits enables lakh and crore for Burmese amounts when the
+// flag is set or the configuration asks for them.
+func useTraditionalUnits(flagValue bool) {
+	cfg, _ := config.Load()
+	i18n.SetTraditionalUnits(flagValue || cfg.MyanmarUnits)
+}
+
+// preferredLang picks the report language from, in order, the --lang flag,
+// the profile, the saved preference and the locale, defaulting to English.
+func preferredLang(flagValue string, prof *profile.Profile) i18n.Lang {
+	var profileLang string
+	if prof != nil {
+		profileLang = string(prof.Language)
+	}
+	// A missing or broken configuration only loses the saved preference.
+	cfg, _ := config.Load()
+	if l, ok := i18n.Preferred(os.Getenv, flagValue, profileLang, cfg.Language); ok {
+		return l
+	}
+	return i18n.EN
+}
+
 func main() {
 
+	// A broken language file only loses that language.
+	if err := i18n.LoadUserDir(); err != nil {
+
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+
+		store, err := history.DefaultStore()
+		if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		useTraditionalUnits(false)
+		os.Exit(runHistory(store, preferredLang("", nil), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "advise" {
+
+		store, err := history.DefaultStore()
+		if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		useTraditionalUnits(false)
+		os.Exit(runAdvise(store, preferredLang("", nil), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "household" {
+
+		store, err := history.DefaultStore()
+		if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		useTraditionalUnits(false)
+		os.Exit(runHousehold(store, preferredLang("", nil), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "lang" {
+
+		os.Exit(runLang(preferredLang("", nil), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "profile" {
+
+		store, err := profile.DefaultStore()
+		if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		useTraditionalUnits(false)
+		os.Exit(runProfile(store, preferredLang("", nil), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	output := flag.String("output", "",
+		"write a report to this file; the format is taken from the extension\n"+
+			"(txt, json, csv, md, html, pdf, xlsx)")
+	templateName := flag.String("template", "",
+		"render the report with a built-in template ("+
+			strings.Join(report.BuiltinTemplates(), ", ")+
+			")\nor a text/template or html/template file; printed unless --output is set")
+	label := flag.String("label", "", "label for the calculation in the saved history")
+	profileName := flag.String("profile", "",
+		"prefill dependents and SSB from a saved profile (see pitcalc profile)")
+	units := flag.Bool("units", false,
+		"write Burmese amounts in lakh and crore (also \"myanmar_units\" in config.json)")
+	fiscalYearFlag := flag.String("fy", "",
+		"fiscal year assessed, e.g. 2026-27 or the October–September 2019-20;\n"+
+			"defaults to the year of --joined or --left, then the current year")
+	joinedFlag := flag.String("joined", "",
+		"date joined (YYYY-MM-DD); with --left, replaces the month prompts")
+	leftFlag := flag.String("left", "",
+		"date left (YYYY-MM-DD); with --joined, replaces the month prompts")
+	prorateFlag := flag.String("prorate", "",
+		"count a month joined or left part way through by \"calendar\" or \"working\" days\n"+
+			"(Monday to Friday) instead of in full; needs --joined or --left")
+	dependentsFlag := flag.String("dependents", "",
+		"JSON file listing the dependents (relationship, birth date, student, disabled,\n"+
+			"lives with you, has an income); replaces the dependent prompts and the\n"+
+			"profile's dependents, and each is checked for eligibility")
+	explain := flag.Bool("explain", false, "show how the tax was worked out, step by step")
+	langFlag := flag.String("lang", "",
+		"report language (EN, MY or a language file's code); defaults to the profile's language,\n"+
+			"the saved preference, then the locale (LC_ALL, LANG)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: pitcalc [flags]\n       pitcalc history list|show|explain|export\n       pitcalc advise <id> [<id>]\n       pitcalc household [flags] <id> <id>\n       pitcalc profile list|show|save|delete\n       pitcalc lang list|check\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var (
+		format report.Format
+		tmpl   *report.Template
+	)
+	if *templateName != "" {
+
+		t, err := report.ResolveTemplate(*templateName)
+		if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(2)
+		}
+		tmpl = t
+	} else if *output != "" {
+
+		f, err := report.ParseFormat(filepath.Ext(*output))
+		if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(2)
+		}
+		format = f
+	}
+
+	if _, ok := i18n.ParseLang(*langFlag); *langFlag != "" && !ok {
+
+		fmt.Fprintln(os.Stderr, "❌ "+i18n.Tf(preferredLang("", nil), "cli_err_lang", *langFlag))
+		os.Exit(2)
+	}
+	lang := preferredLang(*langFlag, nil)
+	useTraditionalUnits(*units)
+
+	var prof *profile.Profile
+	if *profileName != "" {
+
+		p, err := loadProfile(*profileName)
+		if errors.Is(err, profile.ErrNotFound) {
+
+			fmt.Fprintln(os.Stderr, "❌ "+i18n.Tf(lang, "cli_profile_not_found", *profileName))
+			os.Exit(2)
+		} else if err != nil {
+
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(2)
+		}
+		prof = &p
+		if *label == "" {
+
+			*label = p.Name
+		}
+		lang = preferredLang(*langFlag, prof)
+	}
+
+	employment, err := parseEmployment(*fiscalYearFlag, *joinedFlag, *leftFlag)
+	if err != nil {
+
+		fmt.Fprintln(os.Stderr, "❌ "+employmentError(lang, err))
+		os.Exit(2)
+	}
+	proration, err := pitcalc.ParseProration(*prorateFlag)
+	if err != nil {
+
+		fmt.Fprintln(os.Stderr, "❌ "+i18n.Tf(lang, "cli_err_prorate", *prorateFlag))
+		os.Exit(2)
+	}
+	if proration != pitcalc.ProrateNone && *joinedFlag == "" && *leftFlag == "" {
+
+		fmt.Fprintln(os.Stderr, "❌ "+i18n.T(lang, "cli_err_prorate_dates"))
+		os.Exit(2)
+	}
+
+	var dependents []pitcalc.Dependent
+	if *dependentsFlag != "" {
+
+		deps, err := loadDependents(*dependentsFlag)
+		if err != nil {
+
+			fmt.Fprintln(os.Stderr, "❌ "+i18n.Tf(lang, "cli_err_dependents", err))
+			os.Exit(2)
+		}
+		dependents = deps
+	}
+
 	fmt.Println("=====================================")
-	fmt.Println("   🇲🇲 Myanmar PIT Calculator (CLI)")
+	fmt.Println("   " + i18n.T(lang, "cli_title"))
 	fmt.Println("=====================================")
 
-	monthlyIncome := inputInt(
-		"Enter monthly income (MMK): ",
-		validateMonthlyIncome,
+	monthlyIncome := inputInt(lang,
+		i18n.T(lang, "cli_income_prompt"),
+		validateMonthlyIncome(lang),
 	)
 
-	startingMonth := inputInt(
-		"Enter starting month (1 = Jan, 2 = Feb, ..., 12 = Dec): ",
-		validateStartingMonth,
-	)
+	input := pitcalc.CalculatePITInput{
+		MonthlyIncome: float64(monthlyIncome),
+		FiscalYear:    employment.Year,
+	}
+	if *joinedFlag != "" || *leftFlag != "" {
 
-	dependentParents := inputInt(
-		"Enter number of dependent parents (1,000,000 MMK for each): ",
-		validateDependentParents,
-	)
+		input = employment.Apply(input)
+		input.Proration = proration
+	} else {
 
-	dependentSpouse := inputInt(
-		"Do you have a dependent spouse? (1 = Yes, 0 = No): ",
-		validateDependentSpouse,
-	)
+		input.StartingMonth = inputInt(lang,
+			i18n.T(lang, "cli_start_prompt"),
+			validateStartingMonth(lang, input.FiscalYear),
+		)
 
-	childrens := inputInt(
-		"Enter number of children (500,000 MMK for each): ",
-		validateChildrens,
-	)
+		input.EndingMonth = inputInt(lang,
+			endPrompt(lang, input.FiscalYear),
+			validateEndingMonth(lang, input.FiscalYear, input.StartingMonth),
+		)
+	}
+	if prof != nil {
 
-	ssb := inputInt(
-		"Enter total SSB contribution yearly (MMK): ",
-		validateSSB,
-	)
+		fmt.Println(i18n.Tf(lang, "cli_using_profile", prof.Name))
+		input = prof.Apply(input)
+	} else if dependents == nil {
+
+		input.DependentParents = inputInt(lang,
+			i18n.T(lang, "cli_parents_prompt"),
+			validateDependentParents(lang),
+		)
+
+		input.DependentSpouse = inputInt(lang,
+			i18n.T(lang, "cli_spouse_prompt"),
+			validateDependentSpouse(lang),
+		)
+
+		input.Childrens = inputInt(lang,
+			i18n.T(lang, "cli_children_prompt"),
+			validateChildrens(lang),
+		)
+	}
+	if prof == nil {
 
-	output, err := pitcalc.CalculatePIT(
-		pitcalc.CalculatePITInput{
-			MonthlyIncome:    float64(monthlyIncome),
-			StartingMonth:    startingMonth,
-			DependentParents: dependentParents,
-			DependentSpouse:  dependentSpouse,
-			Childrens:        childrens,
-			SSB:              float64(ssb),
-		})
+		input.SSB = float64(inputInt(lang,
+			i18n.T(lang, "cli_ssb_prompt"),
+			validateSSB(lang),
+		))
+	}
+	if dependents != nil {
+
+		input.Dependents = dependents
+	}
+	result, err := pitcalc.CalculatePIT(input)
 	if err != nil {
 
-		fmt.Printf("Error in calculating PIT: %v\n", err)
+		fmt.Println(i18n.Tf(lang, "cli_err_calc", report.ErrorMessage(lang, err)))
+		os.Exit(1)
 	}
 	fmt.Println("=====================================")
-	fmt.Printf(
-		"Total Taxable Income: %s\n", currencyFormat(output.TotalTexable))
-	fmt.Printf("Total Reliefs: %s\n", currencyFormat(output.TotalRelief))
-	fmt.Printf("Total Personal Income Tax: %s\n", currencyFormat(output.TotalTax))
-	sort.Slice(output.TaxBreakdown, func(i, j int) bool {
+	fmt.Printf("%s: %s\n", i18n.T(lang, "res_months"), report.Period(lang, input))
+	for _, p := range report.Proration(lang, result) {
+
+		fmt.Printf("  %s: %s\n", p.Label, p.Income)
+	}
+	fmt.Printf("%s: %s\n", i18n.T(lang, "res_total_income"), currencyFormat(lang, result.TotalTexable))
+	fmt.Printf("%s: %s\n", i18n.T(lang, "res_total_reliefs"), currencyFormat(lang, result.TotalRelief))
+	for _, d := range report.Dependents(lang, result) {
 
-		return output.TaxBreakdown[i].Start < output.TaxBreakdown[j].Start
+		fmt.Printf("  %s: %s (%s)\n", d.Label, d.Decision, d.Relief)
+	}
+	fmt.Printf("%s: %s\n", i18n.T(lang, "cli_total_tax"), currencyFormat(lang, result.TotalTax))
+	sort.Slice(result.TaxBreakdown, func(i, j int) bool {
+
+		return result.TaxBreakdown[i].Start < result.TaxBreakdown[j].Start
 	})
-	for _, v := range output.TaxBreakdown {
+	for _, v := range result.TaxBreakdown {
 
 		if v.Limit == math.Inf(1) {
 
 			fmt.Printf(
-				"  Above from %s: %s\n",
-				currencyFormat(v.Start),
-				currencyFormat(v.Amount))
+				"  %s: %s\n",
+				i18n.Tf(lang, "cli_above", currencyFormat(lang, v.Start)),
+				currencyFormat(lang, v.Amount))
 		} else {
 
 			fmt.Printf(
-				"  Up to %s: %s\n",
-				currencyFormat(v.Limit),
-				currencyFormat(v.Amount))
+				"  %s: %s\n",
+				i18n.Tf(lang, "cli_up_to", currencyFormat(lang, v.Limit)),
+				currencyFormat(lang, v.Amount))
 		}
 	}
 	fmt.Println("=====================================")
+	if *explain {
+
+		printExplanation(os.Stdout, lang, result)
+		fmt.Println("=====================================")
+	}
+
+	if store, err := history.DefaultStore(); err == nil {
+
+		if e, err := store.Add(*label, input, result); err != nil {
+
+			fmt.Fprintf(os.Stderr, "⚠️  %s%v\n", i18n.T(lang, "err_history"), err)
+		} else {
+
+			fmt.Println(i18n.Tf(lang, "cli_history_saved", e.ID))
+		}
+	}
+
+	switch {
+	case tmpl != nil && *output == "":
+
+		if err := tmpl.Execute(os.Stdout, lang, input, result); err != nil {
+
+			fmt.Println("❌ " + i18n.Tf(lang, "cli_err_template", err))
+			os.Exit(1)
+		}
+	case *output != "":
+
+		if tmpl != nil {
+
+			err = tmpl.WriteFile(*output, lang, input, result)
+		} else {
+
+			err = report.WriteFile(*output, format, lang, input, result)
+		}
+		if err != nil {
+
+			fmt.Printf("❌ %s%v\n", i18n.T(lang, "err_export"), err)
+			os.Exit(1)
+		}
+		fmt.Println(i18n.T(lang, "success_export") + *output)
+	}
 }
 
-func inputInt(prompt string, validate func(int) *string) int64 {
+// dateLayout is the format of the --joined and --left dates.
+const dateLayout = "2006-01-02"
+
+// flagError is an invalid flag value, reported with the catalogue message
+// with the given id formatted with arg.
+type flagError struct {
+	id  string
+	arg any
+}
 
-	errMessage := "❌ Invalid input, try again."
+func (e *flagError) Error() string {
+	return fmt.Sprintf("%s: %v", e.id, e.arg)
+}
+
+// employmentError describes an error from parseEmployment in the given
+// language.
+func employmentError(lang i18n.Lang, err error) string {
+	var fe *flagError
+	if errors.As(err, &fe) {
+		if fy, ok := fe.arg.(pitcalc.FiscalYear); ok {
+			return i18n.Tf(lang, fe.id, report.FiscalYear(lang, fy))
+		}
+		return i18n.Tf(lang, fe.id, fe.arg)
+	}
+	return err.Error()
+}
+
+// parseEmployment works out the fiscal year from the --fy flag, or else the
+// year of the first date given or the current year, and the months within it
+// between the --joined and --left dates, which default to the year's first
+// and last days.
+func parseEmployment(fyFlag, joinedFlag, leftFlag string) (pitcalc.Employment, error) {
+	var joined, left time.Time
+	for _, d := range []struct {
+		text string
+		date *time.Time
+	}{{joinedFlag, &joined}, {leftFlag, &left}} {
+		if d.text == "" {
+			continue
+		}
+		t, err := time.Parse(dateLayout, d.text)
+		if err != nil {
+			return pitcalc.Employment{}, &flagError{"cli_err_date", d.text}
+		}
+		*d.date = t
+	}
+
+	fy := pitcalc.CurrentFiscalYear()
+	switch {
+	case fyFlag != "":
+		parsed, err := pitcalc.ParseFiscalYear(fyFlag)
+		if err != nil {
+			return pitcalc.Employment{}, &flagError{"cli_err_fy", fyFlag}
+		}
+		fy = parsed
+	case !joined.IsZero():
+		fy = pitcalc.FiscalYearOf(joined)
+	case !left.IsZero():
+		fy = pitcalc.FiscalYearOf(left)
+	}
+
+	e, err := fy.Employment(joined, left)
+	if err != nil {
+		return pitcalc.Employment{}, &flagError{"cli_err_employment", fy}
+	}
+	return e, nil
+}
+
+// dependentRecord is a dependent as written in a --dependents file, with the
+// birth date as YYYY-MM-DD.
+type dependentRecord struct {
+	Name         string
+	Relationship string
+	BirthDate    string
+	Student      bool
+	Disabled     bool
+	LivesWith    bool
+	HasIncome    bool
+}
+
+// readDependents reads a JSON list of dependents, such as
+//
+//	[{"Name": "Daw Mya", "Relationship": "parent", "LivesWith": true},
+//	 {"Name": "Ko Ko", "Relationship": "child", "BirthDate": "2008-05-01", "Student": true}]
+func readDependents(r io.Reader) ([]pitcalc.Dependent, error) {
+	var records []dependentRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+	deps := make([]pitcalc.Dependent, len(records))
+	for i, rec := range records {
+		deps[i] = pitcalc.Dependent{
+			Name:         rec.Name,
+			Relationship: pitcalc.Relationship(strings.ToLower(strings.TrimSpace(rec.Relationship))),
+			Student:      rec.Student,
+			Disabled:     rec.Disabled,
+			LivesWith:    rec.LivesWith,
+			HasIncome:    rec.HasIncome,
+		}
+		if rec.BirthDate != "" {
+			t, err := time.Parse(dateLayout, rec.BirthDate)
+			if err != nil {
+				return nil, fmt.Errorf("dependent %d: birth date %q is not YYYY-MM-DD", i+1, rec.BirthDate)
+			}
+			deps[i].BirthDate = t
+		}
+	}
+	return deps, nil
+}
+
+// loadDependents reads the --dependents file.
+func loadDependents(path string) ([]pitcalc.Dependent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readDependents(f)
+}
+
+// stdin is shared by all prompts so answers piped in ahead of time are not
+// lost in a per-prompt buffer.
+var stdin = bufio.NewReader(os.Stdin)
+
+func inputInt(lang i18n.Lang, prompt string, validate func(int) *string) int64 {
+
+	errMessage := "❌ " + i18n.T(lang, "cli_err_invalid")
 
-	reader := bufio.NewReader(os.Stdin)
 	for {
 
 		fmt.Print(prompt)
-		text, _ := reader.ReadString('\n')
-		value, err := strconv.Atoi(strings.TrimSpace(text))
+		text, err := stdin.ReadString('\n')
+		if err != nil && text == "" {
+
+			fmt.Println()
+			os.Exit(1)
+		}
+		value, err := parseWhole(text)
 		validationErrMessage := validate(value)
 		if err == nil && validationErrMessage == nil {
 
@@ -113,59 +519,127 @@ func inputInt(prompt string, validate func(int) *string) int64 {
 	}
 }
 
-func currencyFormat(amount float64) string {
+// parseWhole parses a whole number typed in either language, accepting
+// Myanmar digits and shorthand such as "5 lakh" or "500k".
+func parseWhole(text string) (int, error) {
+	v, err := i18n.ParseAmount(text)
+	if err != nil {
+		return 0, err
+	}
+	if v != math.Trunc(v) {
+		return 0, strconv.ErrSyntax
+	}
+	return int(v), nil
+}
+
+func currencyFormat(lang i18n.Lang, amount float64) string {
 
-	return message.NewPrinter(language.English).Sprintf("%.2f MMK", amount)
+	return i18n.FormatCurrency(lang, amount)
 }
 
-func validateMonthlyIncome(value int) *string {
-	if value <= 0 {
-		errMessage := "❌ Monthly income must be greater than 0."
-		return &errMessage
+// validationError returns the message with the given id for an input prompt.
+func validationError(lang i18n.Lang, id string) *string {
+	errMessage := "❌ " + i18n.T(lang, id)
+	return &errMessage
+}
+
+func validateMonthlyIncome(lang i18n.Lang) func(int) *string {
+	return func(value int) *string {
+		if value <= 0 {
+			return validationError(lang, "cli_err_income")
+		}
+		return nil
+	}
+}
+
+// validateStartingMonth checks the starting month falls within the fiscal
+// year, which only the six-month interim years do not cover entirely.
+func validateStartingMonth(lang i18n.Lang, fy pitcalc.FiscalYear) func(int) *string {
+	return func(value int) *string {
+		if value < 1 || value > 12 {
+			return validationError(lang, "cli_err_start")
+		}
+		if _, ok := fy.MonthIndex(int64(value)); !ok {
+			return monthNotInYear(lang, fy)
+		}
+		return nil
 	}
-	return nil
 }
 
-func validateStartingMonth(value int) *string {
-	if value < 1 || value > 12 {
-		errMessage := "❌ Starting month must be between 1 and 12."
-		return &errMessage
+// validateEndingMonth checks the ending month falls on or after the starting
+// month within the fiscal year.
+func validateEndingMonth(lang i18n.Lang, fy pitcalc.FiscalYear, startingMonth int64) func(int) *string {
+	return func(value int) *string {
+		if value < 1 || value > 12 {
+			return validationError(lang, "cli_err_end")
+		}
+		end, ok := fy.MonthIndex(int64(value))
+		if !ok {
+			return monthNotInYear(lang, fy)
+		}
+		if start, _ := fy.MonthIndex(startingMonth); end < start {
+			if fy.IsZero() {
+				return validationError(lang, "cli_err_end_before")
+			}
+			errMessage := "❌ " + i18n.Tf(lang, "cli_err_end_before_fy", report.FiscalYear(lang, fy))
+			return &errMessage
+		}
+		return nil
 	}
-	return nil
 }
 
-func validateDependentParents(value int) *string {
-	if value < 0 {
-		errMessage := "❌ Number of dependent parents cannot be negative."
-		return &errMessage
+func monthNotInYear(lang i18n.Lang, fy pitcalc.FiscalYear) *string {
+	errMessage := "❌ " + i18n.Tf(lang, "cli_err_month_year", report.FiscalYear(lang, fy))
+	return &errMessage
+}
+
+// endPrompt asks for the ending month, suggesting the last month of the
+// fiscal year for staff employed until its end.
+func endPrompt(lang i18n.Lang, fy pitcalc.FiscalYear) string {
+	if fy.IsZero() {
+		return i18n.T(lang, "cli_end_prompt")
 	}
-	if value > 2 {
-		errMessage := "❌ Number of dependent parents cannot exceed 2."
-		return &errMessage
+	months := fy.CalendarMonths()
+	last := months[len(months)-1]
+	return i18n.Tf(lang, "cli_end_prompt_fy", last, i18n.MonthName(lang, last))
+}
+
+func validateDependentParents(lang i18n.Lang) func(int) *string {
+	return func(value int) *string {
+		if value < 0 {
+			return validationError(lang, "cli_err_parents_negative")
+		}
+		if value > pitcalc.MaxDependentParents {
+			errMessage := "❌ " + i18n.Tf(lang, "cli_err_parents_max", pitcalc.MaxDependentParents)
+			return &errMessage
+		}
+		return nil
 	}
-	return nil
 }
 
-func validateDependentSpouse(value int) *string {
-	if value != 0 && value != 1 {
-		errMessage := "❌ Invalid input. Please enter 1 for Yes or 0 for No."
-		return &errMessage
+func validateDependentSpouse(lang i18n.Lang) func(int) *string {
+	return func(value int) *string {
+		if value != 0 && value != 1 {
+			return validationError(lang, "cli_err_spouse")
+		}
+		return nil
 	}
-	return nil
 }
 
-func validateChildrens(value int) *string {
-	if value < 0 {
-		errMessage := "❌ Number of children cannot be negative."
-		return &errMessage
+func validateChildrens(lang i18n.Lang) func(int) *string {
+	return func(value int) *string {
+		if value < 0 {
+			return validationError(lang, "cli_err_children")
+		}
+		return nil
 	}
-	return nil
 }
 
-func validateSSB(value int) *string {
-	if value < 0 {
-		errMessage := "❌ Yearly SSB contribution cannot be negative."
-		return &errMessage
+func validateSSB(lang i18n.Lang) func(int) *string {
+	return func(value int) *string {
+		if value < 0 {
+			return validationError(lang, "cli_err_ssb")
+		}
+		return nil
 	}
-	return nil
 }
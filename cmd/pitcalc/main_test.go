@@ -1,8 +1,17 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/profile"
 )
 
 func TestCurrencyFormat(t *testing.T) {
@@ -45,7 +54,7 @@ func TestCurrencyFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := currencyFormat(tt.amount)
+			result := currencyFormat(i18n.EN, tt.amount)
 			if result != tt.expected {
 				t.Errorf("expected %q, got %q", tt.expected, result)
 			}
@@ -64,123 +73,163 @@ func TestInputValidation(t *testing.T) {
 		{
 			name:       "valid positive income",
 			value:      500000,
-			validator:  validateMonthlyIncome,
+			validator:  validateMonthlyIncome(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:          "zero income",
 			value:         0,
-			validator:     validateMonthlyIncome,
+			validator:     validateMonthlyIncome(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Monthly income must be greater than 0.",
 		},
 		{
 			name:          "negative income",
 			value:         -100000,
-			validator:     validateMonthlyIncome,
+			validator:     validateMonthlyIncome(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Monthly income must be greater than 0.",
 		},
 		{
 			name:       "valid month april",
 			value:      4,
-			validator:  validateStartingMonth,
+			validator:  validateStartingMonth(i18n.EN, pitcalc.FiscalYear{}),
 			shouldPass: true,
 		},
 		{
 			name:          "month 0",
 			value:         0,
-			validator:     validateStartingMonth,
+			validator:     validateStartingMonth(i18n.EN, pitcalc.FiscalYear{}),
 			shouldPass:    false,
 			expectedError: "❌ Starting month must be between 1 and 12.",
 		},
 		{
 			name:          "month 13",
 			value:         13,
-			validator:     validateStartingMonth,
+			validator:     validateStartingMonth(i18n.EN, pitcalc.FiscalYear{}),
 			shouldPass:    false,
 			expectedError: "❌ Starting month must be between 1 and 12.",
 		},
+		{
+			name:       "ending month in the same year",
+			value:      9,
+			validator:  validateEndingMonth(i18n.EN, pitcalc.FiscalYear{}, 7),
+			shouldPass: true,
+		},
+		{
+			name:       "ending month across the new year",
+			value:      2,
+			validator:  validateEndingMonth(i18n.EN, pitcalc.FiscalYear{}, 11),
+			shouldPass: true,
+		},
+		{
+			name:          "ending month 13",
+			value:         13,
+			validator:     validateEndingMonth(i18n.EN, pitcalc.FiscalYear{}, 4),
+			shouldPass:    false,
+			expectedError: "❌ Ending month must be between 1 and 12.",
+		},
+		{
+			name:          "ending month before starting month",
+			value:         6,
+			validator:     validateEndingMonth(i18n.EN, pitcalc.FiscalYear{}, 10),
+			shouldPass:    false,
+			expectedError: "❌ Ending month cannot be before the starting month in the April–March year.",
+		},
 		{
 			name:       "valid one parent",
 			value:      1,
-			validator:  validateDependentParents,
+			validator:  validateDependentParents(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:       "valid two parents",
 			value:      2,
-			validator:  validateDependentParents,
+			validator:  validateDependentParents(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:          "three parents exceeds limit",
 			value:         3,
-			validator:     validateDependentParents,
+			validator:     validateDependentParents(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Number of dependent parents cannot exceed 2.",
 		},
 		{
 			name:          "negative parents",
 			value:         -1,
-			validator:     validateDependentParents,
+			validator:     validateDependentParents(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Number of dependent parents cannot be negative.",
 		},
 		{
 			name:       "spouse yes 1",
 			value:      1,
-			validator:  validateDependentSpouse,
+			validator:  validateDependentSpouse(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:       "spouse no 0",
 			value:      0,
-			validator:  validateDependentSpouse,
+			validator:  validateDependentSpouse(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:          "spouse invalid 2",
 			value:         2,
-			validator:     validateDependentSpouse,
+			validator:     validateDependentSpouse(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Invalid input. Please enter 1 for Yes or 0 for No.",
 		},
 		{
 			name:       "three children valid",
 			value:      3,
-			validator:  validateChildrens,
+			validator:  validateChildrens(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:       "zero children valid",
 			value:      0,
-			validator:  validateChildrens,
+			validator:  validateChildrens(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:          "negative children invalid",
 			value:         -1,
-			validator:     validateChildrens,
+			validator:     validateChildrens(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Number of children cannot be negative.",
 		},
+		{
+			name:          "zero income in Myanmar",
+			value:         0,
+			validator:     validateMonthlyIncome(i18n.MY),
+			shouldPass:    false,
+			expectedError: "❌ လစဉ် ဝင်ငွေသည် 0 ထက် ကြီးရပါမည်။",
+		},
+		{
+			name:          "ending month before starting month in Myanmar",
+			value:         6,
+			validator:     validateEndingMonth(i18n.MY, pitcalc.FiscalYear{}, 10),
+			shouldPass:    false,
+			expectedError: "❌ ဧပြီ–မတ် ဘဏ္ဍာနှစ်တွင် ပြီးဆုံးသည့် လသည် စတင်သည့် လ မတိုင်မီ မဖြစ်ရပါ။",
+		},
 		{
 			name:       "ssb 72000 valid",
 			value:      72000,
-			validator:  validateSSB,
+			validator:  validateSSB(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:       "ssb 0 valid",
 			value:      0,
-			validator:  validateSSB,
+			validator:  validateSSB(i18n.EN),
 			shouldPass: true,
 		},
 		{
 			name:          "ssb negative invalid",
 			value:         -1000,
-			validator:     validateSSB,
+			validator:     validateSSB(i18n.EN),
 			shouldPass:    false,
 			expectedError: "❌ Yearly SSB contribution cannot be negative.",
 		},
@@ -204,8 +253,30 @@ func TestInputValidation(t *testing.T) {
 	}
 }
 
+func TestParseWhole(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"500000\n", 500000, false},
+		{"၅ သိန်း\n", 500000, false},
+		{"1.2M", 1200000, false},
+		{"၂", 2, false},
+		{"-1", -1, false},
+		{"1.5", 0, true},
+		{"two", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWhole(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseWhole(%q): expected %d (error %t), got %d, %v", tt.input, tt.want, tt.wantErr, got, err)
+		}
+	}
+}
+
 func TestCurrencyFormatContainsMMK(t *testing.T) {
-	result := currencyFormat(12345.67)
+	result := currencyFormat(i18n.EN, 12345.67)
 	if !strings.Contains(result, "MMK") {
 		t.Errorf("expected format to contain 'MMK', got %q", result)
 	}
@@ -236,10 +307,379 @@ func TestCurrencyFormatTwoDecimalPlaces(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := currencyFormat(tt.amount)
+			result := currencyFormat(i18n.EN, tt.amount)
 			if result != tt.expected {
 				t.Errorf("expected %q, got %q", tt.expected, result)
 			}
 		})
 	}
 }
+
+func TestRunHistory(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(filepath.Join(dir, "history.json"))
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Add("payroll", in, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A calculation saved before calculations were traced.
+	untraced := *out
+	untraced.Explanation = nil
+	if _, err := store.Add("untraced", in, &untraced); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Add("Untraced", in, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains string
+	}{
+		{"list", []string{"list"}, 0, "payroll"},
+		{"show", []string{"show", "1"}, 0, "TOTAL TAX: 380,000.00 MMK"},
+		{"explain", []string{"explain", "1"}, 0, "5% of 7,600,000.00 MMK"},
+		{"explain untraced", []string{"explain", "2"}, 0, "the sum of the bracket taxes"},
+		{"explain unknown", []string{"explain", "7"}, 1, "not found"},
+		{"show unknown", []string{"show", "7"}, 1, "not found"},
+		{"show by label", []string{"show", "Payroll"}, 0, "TOTAL TAX: 380,000.00 MMK"},
+		{"show unknown label", []string{"show", "x"}, 1, `No saved calculation has the ID or label "x"`},
+		{"show ambiguous label", []string{"show", "untraced"}, 1, `labelled "untraced" (#3, #2)`},
+		{"export", []string{"export", "1", filepath.Join(dir, "r.csv")}, 0, "Exported"},
+		{"export bad format", []string{"export", "1", filepath.Join(dir, "r.doc")}, 2, "unsupported"},
+		{"no command", nil, 2, "usage"},
+		{"unknown command", []string{"purge"}, 2, "usage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := runHistory(store, i18n.EN, tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			if got := stdout.String() + stderr.String(); !strings.Contains(got, tt.contains) {
+				t.Errorf("expected output to contain %q, got %q", tt.contains, got)
+			}
+		})
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	store := profile.NewStore(filepath.Join(t.TempDir(), "profiles.json"))
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains string
+	}{
+		{"list empty", []string{"list"}, 0, "No saved profiles"},
+		{"save", []string{"save", "--parents", "2", "--spouse", "--ssb", "72000", "--lang", "my", "Ko Aung"}, 0, "Saved profile Ko Aung"},
+		{"save invalid", []string{"save", "--parents", "3", "Ko Aung"}, 1, "cannot exceed 2"},
+		{"save bad language", []string{"save", "--lang", "fr", "Ko Aung"}, 1, "Unsupported language"},
+		{"save no name", []string{"save", "--parents", "1"}, 2, "usage"},
+		{"list", []string{"list"}, 0, "Ko Aung"},
+		{"show", []string{"show", "Ko Aung"}, 0, "72,000.00 MMK"},
+		{"show unknown", []string{"show", "Ma Hla"}, 1, "not found"},
+		{"delete", []string{"delete", "Ko Aung"}, 0, "Deleted"},
+		{"delete unknown", []string{"delete", "Ko Aung"}, 1, "not found"},
+		{"no command", nil, 2, "usage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := runProfile(store, i18n.EN, tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			if got := stdout.String() + stderr.String(); !strings.Contains(got, tt.contains) {
+				t.Errorf("expected output to contain %q, got %q", tt.contains, got)
+			}
+		})
+	}
+}
+
+func TestPreferredLang(t *testing.T) {
+	t.Setenv(config.EnvDir, t.TempDir())
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "my_MM.UTF-8")
+	withLang := &profile.Profile{Name: "Ko Aung", Language: i18n.EN}
+
+	tests := []struct {
+		name string
+		flag string
+		prof *profile.Profile
+		want i18n.Lang
+	}{
+		{"flag", "en", withLang, i18n.EN},
+		{"profile", "", withLang, i18n.EN},
+		{"profile without language", "", &profile.Profile{Name: "Ma Hla"}, i18n.MY},
+		{"locale", "", nil, i18n.MY},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preferredLang(tt.flag, tt.prof); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Setenv("LANG", "C")
+	if got := preferredLang("", nil); got != i18n.EN {
+		t.Errorf("expected English by default, got %q", got)
+	}
+	if err := (&config.Config{Language: "MY"}).Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := preferredLang("", nil); got != i18n.MY {
+		t.Errorf("expected saved preference %q, got %q", i18n.MY, got)
+	}
+}
+
+func TestRunAdvise(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(filepath.Join(dir, "history.json"))
+	for _, c := range []struct {
+		label string
+		in    pitcalc.CalculatePITInput
+	}{
+		{"Aung", pitcalc.CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000}},
+		{"", pitcalc.CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, DependentParents: 2, Childrens: 1}},
+	} {
+		out, err := pitcalc.CalculatePIT(c.in)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := store.Add(c.label, c.in, out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains []string
+	}{
+		{"one taxpayer", []string{"1"}, 0, []string{"Tax as entered: 2,272,800.00 MMK", "Aung: SSB is already at the limit."}},
+		{"two taxpayers", []string{"1", "2"}, 0, []string{
+			"1  2 parents, 1 child     0 parents, 0 children  2,162,800.00 MMK  125,000.00 MMK\n",
+			"6  0 parents, 0 children  2 parents, 1 child     2,287,800.00 MMK  0.00 MMK  as entered\n",
+			"saves 125,000.00 MMK a year",
+			"#2: contributing 72,000.00 MMK more to SSB",
+		}},
+		{"unknown entry", []string{"1", "9"}, 1, []string{"not found"}},
+		{"no entries", nil, 2, []string{"usage"}},
+		{"too many entries", []string{"1", "2", "1"}, 2, []string{"usage"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := runAdvise(store, i18n.EN, tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			got := stdout.String() + stderr.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected output to contain %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRunHousehold(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.json"))
+	fy, _ := pitcalc.ParseFiscalYear("2026-27")
+	for _, c := range []struct {
+		label string
+		in    pitcalc.CalculatePITInput
+	}{
+		{"Aung", pitcalc.CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000, DependentParents: 2}},
+		{"Su", pitcalc.CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, DependentParents: 2, DependentSpouse: 1, Childrens: 1}},
+		{"Ko", pitcalc.CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, FiscalYear: fy, Dependents: []pitcalc.Dependent{
+			{Name: "Daw Mya", Relationship: pitcalc.RelationParent, LivesWith: true},
+		}}},
+		{"Hla", pitcalc.CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, FiscalYear: fy, Dependents: []pitcalc.Dependent{
+			{Name: "Daw Mya", Relationship: pitcalc.RelationParent, LivesWith: true},
+			{Name: "Mg Mg", Relationship: pitcalc.RelationChild, BirthDate: time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC)},
+		}}},
+	} {
+		out, err := pitcalc.CalculatePIT(c.in)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := store.Add(c.label, c.in, out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains []string
+	}{
+		{"claimed by number", []string{"1", "2"}, 2, []string{"give --parents and --children"}},
+		{"as claimed", []string{"--parents", "4", "--children", "1", "1", "2"}, 0, []string{
+			"Su claimed the spouse relief",
+			"2 parents, 0 children  2 parents, 1 child",
+			"2,072,800.00 MMK",
+		}},
+		{"claimed twice", []string{"--parents", "2", "--children", "1", "1", "2"}, 1, []string{"The spouses claim more parents or children than the household has"}},
+		{"best claims", []string{"--parents", "2", "--children", "1", "--best", "1", "2"}, 0, []string{
+			"2 parents, 1 child  0 parents, 0 children\n",
+			"2,022,800.00 MMK",
+			"2,162,800.00 MMK",
+			"pays the least tax",
+		}},
+		{"listed by both", []string{"Ko", "Hla"}, 1, []string{"Daw Mya is claimed by both spouses"}},
+		{"listed best claims", []string{"--best", "3", "4"}, 0, []string{
+			"1 parent, 1 child  0 parents, 0 children\n",
+			"Ko:\n  Daw Mya (Parent): Accepted",
+			"Mg Mg (Child): Accepted",
+		}},
+		{"one entry", []string{"1"}, 2, []string{"usage"}},
+		{"unknown entry", []string{"1", "9"}, 1, []string{"not found"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := runHousehold(store, i18n.EN, tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			got := stdout.String() + stderr.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected output to contain %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRunHistory_Myanmar(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.json"))
+	var stdout, stderr strings.Builder
+	if code := runHistory(store, i18n.MY, []string{"list"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if got, want := stdout.String(), i18n.T(i18n.MY, "history_empty")+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	stdout.Reset()
+	if code := runHistory(store, i18n.MY, []string{"show", "4"}, &stdout, &stderr); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if got, want := stderr.String(), "❌ "+i18n.Tf(i18n.MY, "cli_history_not_found", 4)+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRunLang(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ksw.json")
+	data := `{"lang": "ksw", "name": "ကညီ", "messages": {"title": "Karen title", "titel": "typo"}}`
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains string
+	}{
+		{"check built-in", []string{"check", "MY"}, 0, "all strings translated"},
+		{"check file", []string{"check", file}, 1, "untranslated strings"},
+		{"check unknown ids", []string{"check", "ksw"}, 1, "1 string not in English"},
+		{"list", []string{"list"}, 0, "ကညီ"},
+		{"check bad language", []string{"check", "fr"}, 2, "Unsupported language"},
+		{"check missing file", []string{"check", "missing.json"}, 1, "missing.json"},
+		{"no command", nil, 2, "usage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := runLang(i18n.EN, tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			if got := stdout.String() + stderr.String(); !strings.Contains(got, tt.contains) {
+				t.Errorf("expected output to contain %q, got %q", tt.contains, got)
+			}
+		})
+	}
+}
+
+func TestParseEmployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		fy         string
+		joined     string
+		left       string
+		year       string
+		start, end int64
+		err        string
+	}{
+		{"year of joining", "", "2026-06-20", "", "2026-27", 6, 3, ""},
+		{"year of leaving", "", "", "2020-03-15", "2019-20", 10, 3, ""},
+		{"explicit year", "2025-26", "2025-04-01", "2025-09-30", "2025-26", 4, 9, ""},
+		{"interim year", "2018", "", "", "2018", 4, 9, ""},
+		{"bad date", "", "20/06/2026", "", "", 0, 0, `Invalid date "20/06/2026"`},
+		{"bad year", "2026", "", "", "", 0, 0, `Unknown fiscal year "2026"`},
+		{"dates outside the year", "2025-26", "2026-06-20", "", "", 0, 0, "not within FY 2025-26"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := parseEmployment(tt.fy, tt.joined, tt.left)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(employmentError(i18n.EN, err), tt.err) {
+					t.Errorf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e.Year.Code() != tt.year || e.StartingMonth != tt.start || e.EndingMonth != tt.end {
+				t.Errorf("expected %s months %d–%d, got %s months %d–%d", tt.year, tt.start, tt.end, e.Year.Code(), e.StartingMonth, e.EndingMonth)
+			}
+		})
+	}
+
+	_, err := parseEmployment("2025-26", "2026-06-20", "")
+	if got := employmentError(i18n.MY, err); !strings.Contains(got, "၂၀၂၅-၂၆ ဘဏ္ဍာနှစ်") {
+		t.Errorf("expected the fiscal year in Burmese, got %q", got)
+	}
+}
+
+func TestReadDependents(t *testing.T) {
+	deps, err := readDependents(strings.NewReader(`[
+		{"Name": "Daw Mya", "Relationship": "Parent", "LivesWith": true},
+		{"Name": "Ko Ko", "Relationship": "child", "BirthDate": "2008-05-01", "Student": true}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 2 || deps[0].Relationship != pitcalc.RelationParent || !deps[0].LivesWith {
+		t.Errorf("expected a parent living with the taxpayer, got %+v", deps)
+	}
+	if !deps[1].BirthDate.Equal(time.Date(2008, time.May, 1, 0, 0, 0, 0, time.UTC)) || !deps[1].Student {
+		t.Errorf("expected a student born on 1 May 2008, got %+v", deps[1])
+	}
+
+	for _, bad := range []string{`{"Name": "Ko Ko"}`, `[{"BirthDate": "01/05/2008"}]`} {
+		if _, err := readDependents(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/profile"
+	"github.com/myanmar-pit-calculator/pkg/report"
+)
+
+const profileUsage = `usage:
+  pitcalc profile list              list saved profiles
+  pitcalc profile show <name>       print a profile
+  pitcalc profile save [flags] <name>
+                                    create or replace a profile
+  pitcalc profile delete <name>     delete a profile`
+
+// loadProfile reads a profile from the default store.
+func loadProfile(name string) (profile.Profile, error) {
+	store, err := profile.DefaultStore()
+	if err != nil {
+		return profile.Profile{}, err
+	}
+	return store.Get(name)
+}
+
+// yesNo spells a flag as Yes or No.
+func yesNo(lang i18n.Lang, b bool) string {
+	if b {
+		return i18n.T(lang, "cli_yes")
+	}
+	return i18n.T(lang, "cli_no")
+}
+
+func printProfile(w io.Writer, lang i18n.Lang, p profile.Profile) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s:\t%s\n", i18n.T(lang, "cli_name"), p.Name)
+	fmt.Fprintf(tw, "%s:\t%s\n", i18n.T(lang, "cli_employee_id"), p.EmployeeID)
+	fmt.Fprintf(tw, "%s:\t%d\n", i18n.T(lang, "res_parent_relief"), p.DependentParents)
+	fmt.Fprintf(tw, "%s:\t%s\n", i18n.T(lang, "res_spouse_relief"), yesNo(lang, p.DependentSpouse))
+	fmt.Fprintf(tw, "%s:\t%d\n", i18n.T(lang, "res_child_relief"), p.Children)
+	fmt.Fprintf(tw, "%s:\t%s\n", i18n.T(lang, "res_ssb_relief"), currencyFormat(lang, p.SSB))
+	if len(p.Dependents) > 0 {
+		var names []string
+		for _, d := range p.Dependents {
+			names = append(names, report.DependentLabel(lang, d))
+		}
+		fmt.Fprintf(tw, "%s:\t%s\n", i18n.T(lang, "cli_dependents"), strings.Join(names, ", "))
+	}
+	fmt.Fprintf(tw, "%s:\t%s\n", i18n.T(lang, "cli_language"), p.Language)
+	tw.Flush()
+}
+
+// validateProfile checks the profile with the same messages as the prompts.
+func validateProfile(lang i18n.Lang, p profile.Profile) *string {
+	for _, msg := range []*string{
+		validateDependentParents(lang)(int(p.DependentParents)),
+		validateChildrens(lang)(int(p.Children)),
+		validateSSB(lang)(int(p.SSB)),
+	} {
+		if msg != nil {
+			return msg
+		}
+	}
+	if _, ok := i18n.ParseLang(string(p.Language)); p.Language != "" && !ok {
+		errMessage := "❌ " + i18n.Tf(lang, "cli_err_lang", p.Language)
+		return &errMessage
+	}
+	return nil
+}
+
+// runProfile implements the profile subcommand and returns the exit code.
+func runProfile(store *profile.Store, lang i18n.Lang, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, profileUsage)
+		return 2
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		profiles, err := store.List()
+		if err != nil {
+			fmt.Fprintf(stderr, "❌ %v\n", err)
+			return 1
+		}
+		if len(profiles) == 0 {
+			fmt.Fprintln(stdout, i18n.T(lang, "cli_profile_empty"))
+			return 0
+		}
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, i18n.T(lang, "cli_profile_header"))
+		for _, p := range profiles {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%d\t%s\n",
+				p.Name, p.EmployeeID, p.DependentParents, yesNo(lang, p.DependentSpouse), p.Children, currencyFormat(lang, p.SSB))
+		}
+		tw.Flush()
+		return 0
+
+	case args[0] == "show" && len(args) == 2:
+		p, err := store.Get(args[1])
+		if err != nil {
+			printProfileError(stderr, lang, err, args[1])
+			return 1
+		}
+		printProfile(stdout, lang, p)
+		return 0
+
+	case args[0] == "save":
+		fs := flag.NewFlagSet("profile save", flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		var p profile.Profile
+		var profileLang string
+		fs.StringVar(&p.EmployeeID, "employee-id", "", "employee ID")
+		fs.Int64Var(&p.DependentParents, "parents", 0, "number of dependent parents (0-2)")
+		fs.BoolVar(&p.DependentSpouse, "spouse", false, "has a dependent spouse")
+		fs.Int64Var(&p.Children, "children", 0, "number of children")
+		fs.Float64Var(&p.SSB, "ssb", 0, "yearly SSB contribution (MMK)")
+		fs.StringVar(&profileLang, "lang", "", "preferred language (EN, MY or a language file's code)")
+		dependents := fs.String("dependents", "", "JSON file listing the dependents, as for pitcalc --dependents")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if fs.NArg() != 1 {
+			fmt.Fprintln(stderr, profileUsage)
+			return 2
+		}
+		p.Name = fs.Arg(0)
+		p.Language = i18n.Lang(strings.ToUpper(profileLang))
+		if *dependents != "" {
+			deps, err := loadDependents(*dependents)
+			if err != nil {
+				fmt.Fprintln(stderr, "❌ "+i18n.Tf(lang, "cli_err_dependents", err))
+				return 1
+			}
+			p.Dependents = deps
+		}
+		if msg := validateProfile(lang, p); msg != nil {
+			fmt.Fprintln(stderr, *msg)
+			return 1
+		}
+		if err := store.Save(p); err != nil {
+			fmt.Fprintf(stderr, "❌ %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, i18n.Tf(lang, "cli_profile_saved", p.Name))
+		return 0
+
+	case args[0] == "delete" && len(args) == 2:
+		if err := store.Delete(args[1]); err != nil {
+			printProfileError(stderr, lang, err, args[1])
+			return 1
+		}
+		fmt.Fprintln(stdout, i18n.Tf(lang, "cli_profile_deleted", args[1]))
+		return 0
+	}
+
+	fmt.Fprintln(stderr, profileUsage)
+	return 2
+}
+
+// printProfileError reports a failed profile lookup.
+func printProfileError(w io.Writer, lang i18n.Lang, err error, name string) {
+	if errors.Is(err, profile.ErrNotFound) {
+		fmt.Fprintln(w, "❌ "+i18n.Tf(lang, "cli_profile_not_found", name))
+		return
+	}
+	fmt.Fprintf(w, "❌ %v\n", err)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// --- Result Charts ---
+
+// defaultChartWidth is used before the terminal size is known.
+const defaultChartWidth = 80
+
+// Sparkline levels from lowest to highest, and the partial blocks used to
+// draw bar ends at eighth-of-a-cell precision.
+var (
+	sparkLevels = []rune("▁▂▃▄▅▆▇█")
+	barEighths  = []rune(" ▏▎▍▌▋▊▉")
+)
+
+var (
+	chartBarStyle  = lipgloss.NewStyle().Foreground(themePrimary)
+	chartLineStyle = lipgloss.NewStyle().Foreground(themeSecondary)
+	chartAxisStyle = lipgloss.NewStyle().Foreground(themeBorder)
+)
+
+// bar draws a horizontal bar of value/total of width cells.
+func bar(value, total float64, width int) string {
+	if total <= 0 || value <= 0 || width <= 0 {
+		return ""
+	}
+	eighths := int(value / total * float64(width*8))
+	s := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		s += string(barEighths[rest])
+	}
+	return s
+}
+
+// sparkline maps each value onto one of eight block heights between the
+// smallest and largest value.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// buildBracketChart renders the tax paid in each bracket as horizontal bars
+// scaled to fit width.
+func buildBracketChart(l langKey, c *pitcalc.CalculatePITOutput, width int) string {
+	breakdown := c.TaxBreakdown
+	if len(breakdown) == 0 {
+		return ""
+	}
+	largest := 0.0
+	amountWidth := 0
+	for _, v := range breakdown {
+		largest = max(largest, v.Amount)
+		amountWidth = max(amountWidth, lipgloss.Width(currencyFormat(l, v.Amount)))
+	}
+
+	const labelWidth = 5
+	barWidth := max(width-labelWidth-amountWidth-4, 10)
+
+	var b strings.Builder
+	b.WriteString(successStyle.Render(t(l, "chart_brackets")) + "\n")
+	for _, v := range breakdown {
+		fmt.Fprintf(&b, "%s %s %s %s\n",
+			padLeft(i18n.Digits(l, fmt.Sprintf("%.0f%%", v.Rate*100)), labelWidth),
+			chartAxisStyle.Render("│"),
+			chartBarStyle.Render(fmt.Sprintf("%-*s", barWidth, bar(v.Amount, largest, barWidth))),
+			padLeft(currencyFormat(l, v.Amount), amountWidth))
+	}
+	return b.String()
+}
+
+// sensitivityPoints calculates the tax for n monthly incomes spread evenly
+// from half to one and a half times the input's income. It returns the total
+// tax and effective rate at each income and the index closest to the input.
+func sensitivityPoints(in pitcalc.CalculatePITInput, n int) (taxes, rates []float64, current int) {
+	if n < 2 {
+		n = 2
+	}
+	lo, hi := in.MonthlyIncome*0.5, in.MonthlyIncome*1.5
+	step := (hi - lo) / float64(n-1)
+	current = int((in.MonthlyIncome-lo)/step + 0.5)
+	for i := 0; i < n; i++ {
+		point := in
+		point.MonthlyIncome = lo + float64(i)*step
+		tax, rate := 0.0, 0.0
+		if out, err := pitcalc.CalculatePIT(point); err == nil {
+			tax = out.TotalTax
+			if out.GrossIncome > 0 {
+				rate = out.TotalTax / out.GrossIncome
+			}
+		}
+		taxes = append(taxes, tax)
+		rates = append(rates, rate)
+	}
+	return taxes, rates, current
+}
+
+// buildSensitivityChart renders sparklines of the total tax and effective
+// rate for incomes around the user's salary, one point per column.
+func buildSensitivityChart(l langKey, in pitcalc.CalculatePITInput, width int) string {
+	if in.MonthlyIncome <= 0 {
+		return ""
+	}
+	const labelWidth = 16
+	points := max(width-labelWidth-1, 10)
+	taxes, rates, current := sensitivityPoints(in, points)
+
+	label := func(id string) string {
+		return padRight(t(l, id), labelWidth)
+	}
+	marker := strings.Repeat(" ", current) + "▲ " + t(l, "chart_you")
+
+	var b strings.Builder
+	b.WriteString(successStyle.Render(t(l, "chart_sensitivity")) + "\n")
+	b.WriteString(label("chart_total_tax") + " " + chartLineStyle.Render(sparkline(taxes)) + "\n")
+	b.WriteString(label("chart_effective") + " " + chartLineStyle.Render(sparkline(rates)) + "\n")
+	b.WriteString(strings.Repeat(" ", labelWidth+1) + chartAxisStyle.Render(marker) + "\n")
+	fmt.Fprintf(&b, "%s %s … %s\n", strings.Repeat(" ", labelWidth),
+		chartAxisStyle.Render(currencyFormat(l, in.MonthlyIncome*0.5)),
+		chartAxisStyle.Render(currencyFormat(l, in.MonthlyIncome*1.5)))
+	fmt.Fprintf(&b, "%s %s: %s\n", strings.Repeat(" ", labelWidth), t(l, "chart_effective"),
+		i18n.Digits(l, fmt.Sprintf("%.2f%% → %.2f%%", rates[0]*100, rates[len(rates)-1]*100)))
+	return b.String()
+}
+
+// padRight pads s with spaces to width terminal cells. Burmese text has
+// combining marks, so padding is based on display width rather than runes.
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// padLeft right-aligns s in width terminal cells.
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
+}
+
+// chartWidth returns the width available to charts in the result viewport.
+func (m *model) chartWidth() int {
+	if m.viewport.Width > 0 {
+		return m.viewport.Width
+	}
+	return defaultChartWidth
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// --- Persistent History ---
+
+var historyCursorStyle = lipgloss.NewStyle().Foreground(themePrimary).Bold(true)
+
+// recordHistory saves the current calculation to the history file. Failing
+// to save is reported but does not affect the result.
+func (m *model) recordHistory() {
+	m.historyID = 0
+	if m.historyStore == nil {
+		return
+	}
+	e, err := m.historyStore.Add("", m.calcInput, m.calcResult)
+	if err != nil {
+		m.actionAlert = t(m.selectedLang, "err_history") + err.Error()
+		return
+	}
+	m.historyID = e.ID
+}
+
+// openHistory loads the saved calculations and shows the history browser.
+func (m *model) openHistory() {
+	m.state = stateHistory
+	m.actionAlert = ""
+	m.historyCursor = 0
+	m.savedHistory = nil
+	if m.historyStore == nil {
+		return
+	}
+	entries, err := m.historyStore.List()
+	if err != nil {
+		m.actionAlert = t(m.selectedLang, "err_history") + err.Error()
+	}
+	m.savedHistory = entries
+}
+
+// setFormValues fills the tax form from a saved input. A bonus is already
+// folded into the monthly income, so it is not restored separately.
+func (m *model) setFormValues(in pitcalc.CalculatePITInput) {
+	m.valSalary = strconv.FormatFloat(in.MonthlyIncome, 'f', -1, 64)
+	m.valBonus = ""
+	m.valFiscalYear = in.FiscalYear.Code()
+	if in.FiscalYear.IsZero() {
+		m.valFiscalYear = pitcalc.CurrentFiscalYear().Code()
+	}
+	m.valStartMonth = in.StartingMonth
+	m.valEndMonth = in.LastMonth()
+	m.valSpouse = in.DependentSpouse == 1
+	m.valChildren = strconv.FormatInt(in.Childrens, 10)
+	m.valParents = strconv.FormatInt(in.DependentParents, 10)
+	m.valSSB = strconv.FormatFloat(in.SSB, 'f', -1, 64)
+	m.dependents = in.Dependents
+}
+
+// updateHistory handles keys in the history browser: reopen, duplicate into
+// a new calculation, or delete the selected entry.
+func (m *model) updateHistory(key string) {
+	switch key {
+	case "up", "k":
+		if m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	case "down", "j":
+		if m.historyCursor < len(m.savedHistory)-1 {
+			m.historyCursor++
+		}
+	case "b", "esc":
+		m.state = stateResult
+		m.viewport.SetContent(buildResultView(m))
+	}
+	if len(m.savedHistory) == 0 {
+		return
+	}
+	selected := m.savedHistory[m.historyCursor]
+
+	switch key {
+	case "enter", "o":
+		m.state = stateResult
+		m.errMessage = ""
+		m.actionAlert = ""
+		m.calcInput = selected.Input
+		m.calcResult = selected.Output
+		m.historyID = selected.ID
+		m.setFormValues(selected.Input)
+		m.viewport.SetContent(buildResultView(m))
+	case "d":
+		m.setFormValues(selected.Input)
+		m.state = stateForm
+		m.actionAlert = ""
+		m.initTaxForm()
+	case "x", "delete":
+		if err := m.historyStore.Delete(selected.ID); err != nil {
+			m.actionAlert = t(m.selectedLang, "err_history") + err.Error()
+			return
+		}
+		m.savedHistory = append(m.savedHistory[:m.historyCursor], m.savedHistory[m.historyCursor+1:]...)
+		if m.historyCursor >= len(m.savedHistory) && m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	}
+}
+
+// buildHistoryBrowser lists the saved calculations, newest first, with the
+// selected one highlighted.
+func buildHistoryBrowser(m *model) string {
+	l := m.selectedLang
+	footer := lipgloss.NewStyle().Foreground(themeBorder).Render(t(l, "history_footer"))
+	if m.actionAlert != "" {
+		footer = errorStyle.Render(m.actionAlert) + "\n" + footer
+	}
+	title := successStyle.Render(t(l, "history_title"))
+	if len(m.savedHistory) == 0 {
+		return title + "\n\n" + t(l, "history_empty") + "\n\n" + footer
+	}
+
+	title += "  " + i18n.Tf(l, "history_count", len(m.savedHistory))
+
+	var b strings.Builder
+	for i, e := range m.savedHistory {
+		line := fmt.Sprintf("#%-4d %s  %s: %s  %s: %s",
+			e.ID, e.Timestamp.Local().Format("2006-01-02 15:04"),
+			t(l, "res_gross_income"), currencyFormat(l, e.Output.GrossIncome),
+			t(l, "res_final_tax"), currencyFormat(l, e.Output.TotalTax))
+		if e.Label != "" {
+			line += "  " + e.Label
+		}
+		if e.RuleSet != pitcalc.RuleSetVersion {
+			line += "  " + errorStyle.Render(fmt.Sprintf("[%s %s]", t(l, "history_rules"), e.RuleSet))
+		}
+		if i == m.historyCursor {
+			b.WriteString(historyCursorStyle.Render("› "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	return title + "\n\n" + b.String() + "\n" + footer
+}
+
+// defaultHistoryStore opens the history file, or returns nil when the
+// configuration directory is unavailable.
+func defaultHistoryStore() *history.Store {
+	s, err := history.DefaultStore()
+	if err != nil {
+		return nil
+	}
+	return s
+}
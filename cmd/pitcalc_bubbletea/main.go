@@ -1,27 +1,31 @@
 package main
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	lgtable "github.com/charmbracelet/lipgloss/table"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/history"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
 	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/profile"
+	"github.com/myanmar-pit-calculator/pkg/report"
 )
 
 // To satisfy the compiler for now
@@ -53,93 +57,21 @@ var (
 )
 
 // --- T003: Translation Map ---
-type langKey string
+type langKey = i18n.Lang
 
 const (
-	langEN langKey = "EN"
-	langMY langKey = "MY"
+	langEN = i18n.EN
+	langMY = i18n.MY
 )
 
-var trans = map[langKey]map[string]string{
-	langEN: {
-		"title":             "🇲🇲 Myanmar PIT Calculator",
-		"lang_prompt":       "Select Language",
-		"income_group":      "Income Details",
-		"salary_prompt":     "Monthly Salary (MMK)",
-		"bonus_prompt":      "Yearly Bonus (MMK) [Optional]",
-		"reliefs_group":     "Tax Reliefs",
-		"spouse_prompt":     "Dependent Spouse?",
-		"spouse_desc":       "Is your spouse currently unemployed or not earning?",
-		"children_prompt":   "Number of Dependent Children",
-		"parents_prompt":    "Number of Dependent Parents",
-		"other_group":       "Other Allowances",
-		"ssb_prompt":        "Total SSB Contribution (MMK)",
-		"calculating":       "Calculating...",
-		"err_validation":    "❌ Invalid input, please fix errors.",
-		"err_numeric":       "Must be a valid number",
-		"err_negative":      "Cannot be negative",
-		"err_parents":       "Parents must be 0, 1, or 2",
-		"err_ssb":           "Maximum SSB is 360,000",
-		"res_income":        "📊 Income Details",
-		"res_reliefs":       "🛡️  Tax Reliefs",
-		"res_total_income":  "Total Taxable Income",
-		"res_total_reliefs": "Total Reliefs",
-		"res_final_tax":     "💎 Final Tax",
-		"export_prompt":     "Choose Export Format",
-		"success_copy":      "📋 Copied to clipboard!",
-		"success_export":    "📁 Exported to PIT_Report.",
-		"help_footer":       "c: Copy to clipboard • e: Export file • q: Quit",
-		"res_gross_income":  "Gross Income (Yearly)",
-		"res_basic_relief":  "Basic (20%, max 10M)",
-		"res_parent_relief": "Parents",
-		"res_spouse_relief": "Spouse",
-		"res_child_relief":  "Children",
-		"res_ssb_relief":    "SSB",
-	},
-	langMY: {
-		"title":             "🇲🇲 မြန်မာ ဝင်ငွေခွန် တွက်စက်",
-		"lang_prompt":       "ဘာသာစကား ရွေးချယ်ပါ",
-		"income_group":      "ဝင်ငွေ အသေးစိတ်",
-		"salary_prompt":     "လစဉ်လစာ (ကျပ်)",
-		"bonus_prompt":      "နှစ်စဉ် ဆုကြေး (ကျပ်) [ရွေးချယ်ရန်]",
-		"reliefs_group":     "အခွန်သက်သာခွင့်များ",
-		"spouse_prompt":     "မှီခို ဇနီး/ခင်ပွန်း ရှိပါသလား?",
-		"spouse_desc":       "အလုပ်လုပ်ကိုင်ခြင်းမရှိသော အိမ်ထောင်ဖက်",
-		"children_prompt":   "မှီခို ကလေး အရေအတွက်",
-		"parents_prompt":    "မှီခို မိဘ အရေအတွက်",
-		"other_group":       "အခြားသော ခွင့်ပြုချက်များ",
-		"ssb_prompt":        "လူမှုဖူလုံရေး ထည့်ဝင်ငွေ စုစုပေါင်း (ကျပ်)",
-		"calculating":       "တွက်ချက်နေပါသည်...",
-		"err_validation":    "❌ ထည့်သွင်းထားသော အချက်အလက်များ မှားယွင်းနေပါသည်။",
-		"err_numeric":       "ကိန်းဂဏန်းသာ ဖြစ်ရမည်",
-		"err_negative":      "အနုတ်မရပါ",
-		"err_parents":       "မိဘ ယောက်ရေ ၀, ၁, သို့မဟုတ် ၂ သာ ထည့်ပါ",
-		"err_ssb":           "အများဆုံး ထည့်ဝင်ငွေ ၃၆၀,၀၀၀ ဖြစ်သည်",
-		"res_income":        "📊 ဝင်ငွေ အသေးစိတ်",
-		"res_reliefs":       "🛡️  အခွန်သက်သာခွင့်များ",
-		"res_total_income":  "အခွန်စည်းကြပ်ရန် ဝင်ငွေ",
-		"res_total_reliefs": "သက်သာခွင့် စုစုပေါင်း",
-		"res_final_tax":     "💎 ကျသင့် အခွန်ငွေ",
-		"export_prompt":     "ပို့ဆောင်မည့် ပုံစံရွေးပါ",
-		"success_copy":      "📋 ကူးယူပြီးပါပြီ!",
-		"success_export":    "📁 PIT_Report သို့ မှတ်တမ်းတင်ပြီးပါပြီ။",
-		"help_footer":       "c: ကူးယူမည် • e: ဖိုင်ထုတ်မည် • q: ထွက်မည်",
-		"res_gross_income":  "နှစ်စဉ် စုစုပေါင်း ဝင်ငွေ",
-		"res_basic_relief":  "အခြေခံ (၂၀% အများဆုံး သိန်း ၁၀၀)",
-		"res_parent_relief": "မိဘ",
-		"res_spouse_relief": "အိမ်ထောင်ဖက်",
-		"res_child_relief":  "ကလေး",
-		"res_ssb_relief":    "လူမှုဖူလုံရေး",
-	},
-}
-
+// parseNumericInput parses an amount typed in either language, such as
+// "1,000,000", "၅၀၀၀၀၀", "5 lakh" or "500k". Empty input is zero.
 func parseNumericInput(input string) (*float64, error) {
-	clean := strings.ReplaceAll(strings.TrimSpace(input), ",", "")
 	value := 0.0
-	if clean == "" {
+	if strings.TrimSpace(input) == "" {
 		return &value, nil
 	}
-	value, err := strconv.ParseFloat(clean, 64)
+	value, err := i18n.ParseAmount(input)
 	if err != nil {
 		return nil, errors.New("invalid numeric format")
 	}
@@ -171,13 +103,49 @@ func validateParents(l langKey) func(string) error {
 		if err != nil {
 			return errors.New(t(l, "err_numeric"))
 		}
-		if val != nil && (*val < 0 || *val > 2) {
+		if val != nil && (*val < 0 || *val > pitcalc.MaxDependentParents) {
 			return errors.New(t(l, "err_parents"))
 		}
 		return nil
 	}
 }
 
+func validateTemplate(l langKey) func(string) error {
+	return func(s string) error {
+		if _, err := report.ResolveTemplate(strings.TrimSpace(s)); err != nil {
+			return errors.New(t(l, "err_template"))
+		}
+		return nil
+	}
+}
+
+// validateStartMonth rejects a starting month outside the selected fiscal
+// year, which only happens in the six-month interim years.
+func validateStartMonth(l langKey, year *string) func(int64) error {
+	return func(start int64) error {
+		if _, ok := fiscalYear(*year).MonthIndex(start); !ok {
+			return errors.New(t(l, "err_month_year"))
+		}
+		return nil
+	}
+}
+
+// validateEndMonth rejects an ending month outside the selected fiscal year
+// or before the selected starting month.
+func validateEndMonth(l langKey, year *string, start *int64) func(int64) error {
+	return func(end int64) error {
+		fy := fiscalYear(*year)
+		endIdx, ok := fy.MonthIndex(end)
+		if !ok {
+			return errors.New(t(l, "err_month_year"))
+		}
+		if startIdx, _ := fy.MonthIndex(*start); endIdx < startIdx {
+			return errors.New(t(l, "err_end_month"))
+		}
+		return nil
+	}
+}
+
 func validateSSB(l langKey) func(string) error {
 	return func(s string) error {
 		if strings.TrimSpace(s) == "" {
@@ -197,12 +165,15 @@ func validateSSB(l langKey) func(string) error {
 	}
 }
 
+// t returns the string with the given id, in English when the language does
+// not translate it.
 func t(lang langKey, id string) string {
-	return trans[lang][id]
+	return i18n.T(lang, id)
 }
 
-func currencyFormat(amount float64) string {
-	return message.NewPrinter(language.English).Sprintf("%.2f MMK", amount)
+// currencyFormat formats an amount in the display language.
+func currencyFormat(l langKey, amount float64) string {
+	return i18n.FormatCurrency(l, amount)
 }
 
 type state int
@@ -212,72 +183,302 @@ const (
 	stateForm
 	stateResult
 	stateExport
+	stateScenario
+	stateCompare
+	stateHistory
+	stateProfile
+	stateSaveProfile
+	stateAdvice
+	stateExplain
 )
 
+// calculation is a completed calculation kept for the session's history.
+type calculation struct {
+	Input  pitcalc.CalculatePITInput
+	Output *pitcalc.CalculatePITOutput
+}
+
 type model struct {
 	state        state
 	langForm     *huh.Form
 	taxForm      *huh.Form
 	exportForm   *huh.Form
+	scenarioForm *huh.Form
+	profileForm  *huh.Form
 	selectedLang langKey
 	errMessage   string
 	actionAlert  string
+	calcInput    pitcalc.CalculatePITInput
 	calcResult   *pitcalc.CalculatePITOutput
-	viewport     viewport.Model
-
-	valSalary   string
-	valBonus    string
-	valSpouse   bool
-	valChildren string
-	valParents  string
-	valSSB      string
-
+	history      []calculation
+	scenarios    []report.Scenario
+	// advicePartner is the saved scenario, counting from 1, taken as the
+	// second taxpayer on the advisor page, or 0 for none.
+	advicePartner int
+
+	historyStore  *history.Store
+	historyID     int
+	savedHistory  []history.Entry
+	historyCursor int
+
+	// profile is the loaded or last saved profile; its name is offered when
+	// saving the current values as a profile.
+	profileStore  *profile.Store
+	profile       profile.Profile
+	valProfile    string
+	valEmployeeID string
+
+	viewport viewport.Model
+	width    int
+
+	valSalary     string
+	valBonus      string
+	valFiscalYear string // code of the fiscal year, e.g. "2026-27"
+	valStartMonth int64
+	valEndMonth   int64
+	valSpouse     bool
+	valChildren   string
+	valParents    string
+	valSSB        string
+	// dependents lists the dependents in full, from the loaded profile or a
+	// reopened calculation; the counts above are then not asked for.
+	dependents []pitcalc.Dependent
+
+	cfg             *config.Config
+	configBroken    bool
+	langFlag        string
+	exportTime      time.Time
 	valExportFormat string
+	valTemplate     string
+	valExportDir    string
+	valPattern      string
+	valEmployee     string
+	valOverwrite    bool
+	valScenario     string
+
+	// exportComparison exports the scenario comparison instead of the
+	// current result.
+	exportComparison bool
 }
 
-func initialModel() *model {
+// initialModel creates the model. langFlag is the --lang value, or empty to
+// use the saved preference or the locale.
+func initialModel(langFlag string) *model {
+	// A broken configuration file should not keep the calculator from
+	// starting; fall back to the defaults.
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("config: %v", err)
+	}
 	m := &model{
 		state:           stateLang,
 		selectedLang:    langEN,
+		cfg:             cfg,
+		configBroken:    err != nil,
+		langFlag:        langFlag,
+		historyStore:    defaultHistoryStore(),
+		profileStore:    defaultProfileStore(),
+		valFiscalYear:   pitcalc.CurrentFiscalYear().Code(),
+		valStartMonth:   4,
+		valEndMonth:     3,
 		valExportFormat: "txt",
+		valTemplate:     "plain",
+		valExportDir:    cfg.ExportDir,
+		valPattern:      cfg.ExportPattern(),
 	}
 	m.viewport = viewport.New(0, 0)
-
-	m.initLangForm()
+	// h, l and u are result screen keys, so the viewport scrolls with the
+	// arrow keys and ctrl+u/ctrl+d only.
+	m.viewport.KeyMap.Left = key.NewBinding(key.WithKeys("left"))
+	m.viewport.KeyMap.Right = key.NewBinding(key.WithKeys("right"))
+	m.viewport.KeyMap.HalfPageUp = key.NewBinding(key.WithKeys("ctrl+u"))
+	m.viewport.KeyMap.HalfPageDown = key.NewBinding(key.WithKeys("ctrl+d"))
+	i18n.SetTraditionalUnits(cfg.MyanmarUnits)
+
+	switch {
+	case m.initProfileForm():
+		m.state = stateProfile
+	case m.resolveLang(""):
+		m.state = stateForm
+		m.initTaxForm()
+	default:
+		m.initLangForm()
+	}
 	return m
 }
 
+// resolveLang picks the display language from, in order, the --lang flag,
+// the profile's language, the saved preference and the locale. It returns
+// false when none of them is known, so the language screen must ask.
+func (m *model) resolveLang(profileLang langKey) bool {
+	l, ok := i18n.Preferred(os.Getenv, m.langFlag, string(profileLang), m.cfg.Language)
+	if ok {
+		m.selectedLang = l
+	}
+	return ok
+}
+
+// toggleUnits switches Burmese amounts between thousands separators and
+// lakh and crore, and remembers the choice like saveLang.
+func (m *model) toggleUnits() {
+	m.cfg.MyanmarUnits = !i18n.TraditionalUnits()
+	i18n.SetTraditionalUnits(m.cfg.MyanmarUnits)
+	if !m.configBroken {
+		_ = m.cfg.Save()
+	}
+}
+
+// saveLang remembers the language chosen on the language screen. It is only
+// a convenience, so failures are ignored, and a configuration file that
+// could not be read is left alone rather than replaced.
+func (m *model) saveLang() {
+	if m.configBroken || m.cfg.Language == string(m.selectedLang) {
+		return
+	}
+	m.cfg.Language = string(m.selectedLang)
+	_ = m.cfg.Save()
+}
+
+// initLangForm offers the built-in languages and any loaded from catalogue
+// files.
 func (m *model) initLangForm() {
+	var opts []huh.Option[langKey]
+	for _, l := range i18n.Languages() {
+		opts = append(opts, huh.NewOption(i18n.Name(l), l))
+	}
 	m.langForm = huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[langKey]().
 				Title("Select Language / ဘာသာစကား ရွေးချယ်ပါ").
-				Options(
-					huh.NewOption("English", langEN),
-					huh.NewOption("မြန်မာ", langMY),
-				).
+				Options(opts...).
 				Value(&m.selectedLang),
 		),
 	).WithTheme(huh.ThemeDracula())
 	m.langForm.Init()
 }
 
+// exportOptions lists the formats offered for the current export. Scenario
+// comparisons support fewer formats and no templates.
+func (m *model) exportOptions() []huh.Option[string] {
+	formats := report.Formats()
+	if m.exportComparison {
+		formats = report.ComparisonFormats()
+	}
+	var opts []huh.Option[string]
+	supported := false
+	for _, f := range formats {
+		opts = append(opts, huh.NewOption(t(m.selectedLang, "format_"+string(f)), string(f)))
+		supported = supported || string(f) == m.valExportFormat
+	}
+	if m.exportComparison {
+		if !supported {
+			m.valExportFormat = string(report.FormatTXT)
+		}
+		return opts
+	}
+	return append(opts, huh.NewOption(t(m.selectedLang, "format_template"), exportFormatTemplate))
+}
+
+func (m *model) initScenarioForm() {
+	l := m.selectedLang
+	m.valScenario = fmt.Sprintf("%s %d", t(l, "scenario_default"), len(m.scenarios)+1)
+	m.scenarioForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(t(l, "scenario_prompt")).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return errors.New(t(l, "err_scenario"))
+					}
+					return nil
+				}).
+				Value(&m.valScenario),
+		),
+	).WithTheme(huh.ThemeDracula())
+	m.scenarioForm.Init()
+}
+
 func (m *model) initExportForm() {
+	l := m.selectedLang
+	m.exportTime = time.Now()
+	m.valOverwrite = false
 	m.exportForm = huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title(t(m.selectedLang, "export_prompt")).
-				Options(
-					huh.NewOption("TXT Document", "txt"),
-					huh.NewOption("JSON Data", "json"),
-					huh.NewOption("CSV Spreadsheet", "csv"),
-				).
+				Title(t(l, "export_prompt")).
+				Options(m.exportOptions()...).
 				Value(&m.valExportFormat),
 		),
+		huh.NewGroup(
+			huh.NewInput().
+				Title(t(l, "template_prompt")).
+				Description(t(l, "template_desc")+" "+strings.Join(report.BuiltinTemplates(), ", ")).
+				Placeholder("plain").
+				Validate(validateTemplate(l)).
+				Value(&m.valTemplate),
+		).WithHideFunc(func() bool {
+			return m.valExportFormat != exportFormatTemplate
+		}),
+		huh.NewGroup(
+			huh.NewInput().
+				Title(t(l, "export_dir_prompt")).
+				Description(t(l, "export_dir_desc")).
+				Value(&m.valExportDir),
+			huh.NewInput().
+				Title(t(l, "export_pattern_prompt")).
+				Description(t(l, "export_pattern_desc")).
+				Placeholder(config.DefaultFilenamePattern).
+				Value(&m.valPattern),
+			huh.NewInput().
+				Title(t(l, "export_name_prompt")).
+				Value(&m.valEmployee),
+		),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(t(l, "overwrite_prompt")).
+				DescriptionFunc(func() string {
+					path, _ := m.exportPath()
+					return path
+				}, &m.valPattern).
+				Value(&m.valOverwrite),
+		).WithHideFunc(func() bool {
+			return !m.exportExists()
+		}),
 	).WithTheme(huh.ThemeDracula())
 	m.exportForm.Init()
 }
 
+// firstFiscalYear is the earliest year offered on the tax form, the last
+// April–March year before the change to October–September years.
+const firstFiscalYear = 2017
+
+// fiscalYear returns the fiscal year with the given code, or an unspecified
+// April–March year when it is not set.
+func fiscalYear(code string) pitcalc.FiscalYear {
+	fy, _ := pitcalc.ParseFiscalYear(code)
+	return fy
+}
+
+// fiscalYearOptions lists the fiscal years from the current one back to
+// firstFiscalYear, including the October–September and interim years.
+func fiscalYearOptions(l langKey) []huh.Option[string] {
+	var opts []huh.Option[string]
+	for fy := pitcalc.CurrentFiscalYear(); fy.Start().Year() >= firstFiscalYear; fy = fy.Previous() {
+		opts = append(opts, huh.NewOption(report.FiscalYear(l, fy), fy.Code()))
+	}
+	return opts
+}
+
+// monthOptions lists the calendar months of a fiscal year in order.
+func monthOptions(l langKey, fy pitcalc.FiscalYear) []huh.Option[int64] {
+	months := fy.CalendarMonths()
+	opts := make([]huh.Option[int64], len(months))
+	for i, month := range months {
+		opts[i] = huh.NewOption(i18n.MonthName(l, month), month)
+	}
+	return opts
+}
+
 func (m *model) initTaxForm() {
 	l := m.selectedLang
 	m.taxForm = huh.NewForm(
@@ -292,24 +493,29 @@ func (m *model) initTaxForm() {
 				Placeholder("0").
 				Validate(validateNumeric(l)).
 				Value(&m.valBonus),
+			huh.NewSelect[string]().
+				Title(t(l, "fiscal_year_prompt")).
+				Options(fiscalYearOptions(l)...).
+				Value(&m.valFiscalYear),
+			huh.NewSelect[int64]().
+				Title(t(l, "start_month_prompt")).
+				Description(t(l, "start_month_desc")).
+				OptionsFunc(func() []huh.Option[int64] {
+					return monthOptions(l, fiscalYear(m.valFiscalYear))
+				}, &m.valFiscalYear).
+				Validate(validateStartMonth(l, &m.valFiscalYear)).
+				Value(&m.valStartMonth),
+			huh.NewSelect[int64]().
+				Title(t(l, "end_month_prompt")).
+				Description(t(l, "end_month_desc")).
+				OptionsFunc(func() []huh.Option[int64] {
+					return monthOptions(l, fiscalYear(m.valFiscalYear))
+				}, &m.valFiscalYear).
+				Validate(validateEndMonth(l, &m.valFiscalYear, &m.valStartMonth)).
+				Value(&m.valEndMonth),
 		).Title(t(l, "income_group")),
 
-		huh.NewGroup(
-			huh.NewConfirm().
-				Title(t(l, "spouse_prompt")).
-				Description(t(l, "spouse_desc")).
-				Value(&m.valSpouse),
-			huh.NewInput().
-				Title(t(l, "children_prompt")).
-				Placeholder("0").
-				Validate(validateNumeric(l)).
-				Value(&m.valChildren),
-			huh.NewInput().
-				Title(t(l, "parents_prompt")).
-				Placeholder("0").
-				Validate(validateParents(l)).
-				Value(&m.valParents),
-		).Title(t(l, "reliefs_group")),
+		m.reliefsGroup(),
 
 		huh.NewGroup(
 			huh.NewInput().
@@ -323,7 +529,77 @@ func (m *model) initTaxForm() {
 	m.taxForm.Init()
 }
 
-func buildTableString(c *pitcalc.CalculatePITOutput) string {
+// reliefsGroup asks for the dependent counts, or lists the dependents when
+// they are given in full.
+func (m *model) reliefsGroup() *huh.Group {
+	l := m.selectedLang
+	if len(m.dependents) > 0 {
+		var b strings.Builder
+		for _, d := range m.dependents {
+			b.WriteString("• " + report.DependentLabel(l, d) + "\n")
+		}
+		b.WriteString("\n" + t(l, "dependents_listed_desc"))
+		return huh.NewGroup(
+			huh.NewNote().
+				Title(t(l, "res_dependents")).
+				Description(b.String()),
+		).Title(t(l, "reliefs_group"))
+	}
+	return huh.NewGroup(
+		huh.NewConfirm().
+			Title(t(l, "spouse_prompt")).
+			Description(t(l, "spouse_desc")).
+			Value(&m.valSpouse),
+		huh.NewInput().
+			Title(t(l, "children_prompt")).
+			Placeholder("0").
+			Validate(validateNumeric(l)).
+			Value(&m.valChildren),
+		huh.NewInput().
+			Title(t(l, "parents_prompt")).
+			Placeholder("0").
+			Validate(validateParents(l)).
+			Value(&m.valParents),
+	).Title(t(l, "reliefs_group"))
+}
+
+// resetTaxValues clears the tax form for a new calculation.
+func (m *model) resetTaxValues() {
+	m.valSalary = ""
+	m.valBonus = ""
+	m.valFiscalYear = pitcalc.CurrentFiscalYear().Code()
+	m.valStartMonth = 4
+	m.valEndMonth = 3
+	m.valSpouse = false
+	m.valChildren = ""
+	m.valParents = ""
+	m.valSSB = ""
+	m.dependents = m.profile.Dependents
+}
+
+// maxHistoryShown limits the earlier calculations listed on the result screen.
+const maxHistoryShown = 5
+
+// buildHistoryString lists the calculations made before the current one,
+// most recent first.
+func buildHistoryString(l langKey, history []calculation) string {
+	if len(history) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(successStyle.Render(t(l, "res_history")) + "\n")
+	shown := 0
+	for i := len(history) - 2; i >= 0 && shown < maxHistoryShown; i-- {
+		c := history[i].Output
+		fmt.Fprintf(&b, "  #%d  %s: %s  →  %s: %s\n", i+1,
+			t(l, "res_gross_income"), currencyFormat(l, c.GrossIncome),
+			t(l, "res_final_tax"), currencyFormat(l, c.TotalTax))
+		shown++
+	}
+	return b.String()
+}
+
+func buildTableString(l langKey, c *pitcalc.CalculatePITOutput) string {
 	breakdown := c.TaxBreakdown
 	sort.Slice(breakdown, func(i, j int) bool {
 		return breakdown[i].Start < breakdown[j].Start
@@ -333,21 +609,21 @@ func buildTableString(c *pitcalc.CalculatePITOutput) string {
 	for _, v := range breakdown {
 		var limitStr string
 		if v.Limit == math.Inf(1) {
-			limitStr = "And above"
+			limitStr = t(l, "res_and_above")
 		} else {
-			limitStr = currencyFormat(v.Limit)
+			limitStr = currencyFormat(l, v.Limit)
 		}
 		rows = append(rows, []string{
-			currencyFormat(v.Start),
+			currencyFormat(l, v.Start),
 			limitStr,
-			currencyFormat(v.Amount),
+			currencyFormat(l, v.Amount),
 		})
 	}
 
 	t := lgtable.New().
 		Border(lipgloss.NormalBorder()).
 		BorderStyle(lipgloss.NewStyle().Foreground(themeBorder)).
-		Headers("From", "To", "Tax Amount").
+		Headers(t(l, "res_from"), t(l, "res_to"), t(l, "res_tax_amount")).
 		Rows(rows...).
 		StyleFunc(func(row, col int) lipgloss.Style {
 			s := lipgloss.NewStyle().Padding(0, 2)
@@ -368,10 +644,17 @@ func buildResultView(m *model) string {
 	l := m.selectedLang
 
 	// Income Box
-	incomeText := fmt.Sprintf("%s\n%s: %s\n\n%s: %s\n",
+	in := m.calcInput
+	var prorated strings.Builder
+	for _, p := range report.Proration(l, c) {
+		fmt.Fprintf(&prorated, "  %s: %s\n", p.Label, p.Income)
+	}
+	incomeText := fmt.Sprintf("%s\n%s: %s\n%s: %s\n%s\n%s: %s\n",
 		successStyle.Render(t(l, "res_income")),
-		t(l, "res_gross_income"), currencyFormat(c.GrossIncome),
-		t(l, "res_total_income"), currencyFormat(c.TotalTexable))
+		t(l, "res_months"), report.Period(l, in),
+		t(l, "res_gross_income"), currencyFormat(l, c.GrossIncome),
+		prorated.String(),
+		t(l, "res_total_income"), currencyFormat(l, c.TotalTexable))
 
 	incomeBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -383,12 +666,18 @@ func buildResultView(m *model) string {
 	// Reliefs Box
 	reliefsText := fmt.Sprintf("%s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n\n%s: %s\n",
 		successStyle.Render(t(l, "res_reliefs")),
-		t(l, "res_basic_relief"), currencyFormat(c.BasicRelief),
-		t(l, "res_parent_relief"), currencyFormat(c.ParentRelief),
-		t(l, "res_spouse_relief"), currencyFormat(c.SpouseRelief),
-		t(l, "res_child_relief"), currencyFormat(c.ChildRelief),
-		t(l, "res_ssb_relief"), currencyFormat(c.SSBRelief),
-		t(l, "res_total_reliefs"), currencyFormat(c.TotalRelief))
+		t(l, "res_basic_relief"), currencyFormat(l, c.BasicRelief),
+		t(l, "res_parent_relief"), currencyFormat(l, c.ParentRelief),
+		t(l, "res_spouse_relief"), currencyFormat(l, c.SpouseRelief),
+		t(l, "res_child_relief"), currencyFormat(l, c.ChildRelief),
+		t(l, "res_ssb_relief"), currencyFormat(l, c.SSBRelief),
+		t(l, "res_total_reliefs"), currencyFormat(l, c.TotalRelief))
+	if deps := report.Dependents(l, c); len(deps) > 0 {
+		reliefsText += "\n" + successStyle.Render(t(l, "res_dependents")) + "\n"
+		for _, d := range deps {
+			reliefsText += fmt.Sprintf("%s: %s\n  %s\n", d.Label, d.Relief, d.Decision)
+		}
+	}
 
 	reliefsBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -405,9 +694,14 @@ func buildResultView(m *model) string {
 		Padding(1, 2).
 		Background(lipgloss.Color("#1E293B")).
 		Foreground(lipgloss.Color("#F8FAFC")).
-		Render(fmt.Sprintf("%s: %s", t(l, "res_final_tax"), successStyle.Render(currencyFormat(c.TotalTax))))
+		Render(fmt.Sprintf("%s: %s", t(l, "res_final_tax"), successStyle.Render(currencyFormat(l, c.TotalTax))))
 
-	tableRender := "\n" + buildTableString(c) + "\n"
+	tableRender := "\n" + buildTableString(l, c) + "\n"
+	tableRender += "\n" + buildBracketChart(l, c, m.chartWidth())
+	tableRender += "\n" + buildSensitivityChart(l, m.calcInput, m.chartWidth())
+	if h := buildHistoryString(l, m.history); h != "" {
+		tableRender += "\n" + h
+	}
 
 	footer := lipgloss.NewStyle().Foreground(themeBorder).Render(t(l, "help_footer"))
 	if m.actionAlert != "" {
@@ -417,80 +711,360 @@ func buildResultView(m *model) string {
 	return topRow + "\n" + finalBox + "\n" + tableRender + "\n" + footer
 }
 
-func generatePlainTextReport(c *pitcalc.CalculatePITOutput) string {
+// --- Scenario Comparison ---
+
+// buildCompareView renders the saved scenarios side by side with their
+// differences from the first one.
+func buildCompareView(m *model) string {
+	l := m.selectedLang
+	footer := lipgloss.NewStyle().Foreground(themeBorder).Render(t(l, "compare_footer"))
+	if m.actionAlert != "" {
+		footer = successStyle.Render(m.actionAlert) + "\n" + footer
+	}
+	if len(m.scenarios) == 0 {
+		return t(l, "compare_empty") + "\n\n" + footer
+	}
+
+	var rows [][]string
+	for _, row := range report.Compare(l, m.scenarios) {
+		cells := []string{row.Label}
+		for i := range row.Values {
+			cells = append(cells, currencyFormat(l, row.Values[i]))
+			if i > 0 {
+				cells = append(cells, diffStyle(row.ID, row.Diffs[i]).Render(report.SignedCurrency(l, row.Diffs[i])))
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	var headers []string
+	headers = append(headers, t(l, "res_item"))
+	for i, s := range m.scenarios {
+		headers = append(headers, s.Name)
+		if i > 0 {
+			headers = append(headers, t(l, "res_diff"))
+		}
+	}
+
+	table := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(themeBorder)).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			s := lipgloss.NewStyle().Padding(0, 1).Foreground(themeText)
+			if col > 0 {
+				s = s.Align(lipgloss.Right)
+			}
+			return s
+		})
+
+	title := successStyle.Render(t(l, "compare_title")) + "  " + i18n.Tf(l, "compare_count", len(m.scenarios))
+	return title + "\n" + table.Render() + "\n\n" + footer
+}
+
+// --- Tax Advisor ---
+
+// buildAdviceView renders the advisor's findings for the current
+// calculation, paired with a saved scenario as the second taxpayer when one
+// is chosen.
+func buildAdviceView(m *model) string {
+	l := m.selectedLang
+	footer := lipgloss.NewStyle().Foreground(themeBorder).Render(t(l, "advice_footer"))
+	title := successStyle.Render(t(l, "advice_title"))
+
+	inputs := []pitcalc.CalculatePITInput{m.calcInput}
+	names := []string{t(l, "advice_you")}
+	hint := t(l, "advice_unpaired")
+	if m.advicePartner > 0 && m.advicePartner <= len(m.scenarios) {
+		s := m.scenarios[m.advicePartner-1]
+		inputs = append(inputs, s.Input)
+		names = append(names, s.Name)
+		hint = i18n.Tf(l, "advice_paired", s.Name)
+	}
+	a, err := pitcalc.Advise(inputs...)
+	if err != nil {
+		return title + "\n\n" + errorStyle.Render(t(l, "err_prefix")+err.Error()) + "\n\n" + footer
+	}
+
 	var b strings.Builder
-	b.WriteString("Myanmar PIT Calculator Report\n==============================\n")
-	b.WriteString(fmt.Sprintf("Gross Income (Yearly): %s\n", currencyFormat(c.GrossIncome)))
-	b.WriteString("\nReliefs Breakdown:\n")
-	b.WriteString(fmt.Sprintf("  Basic (20%%, max 10M): %s\n", currencyFormat(c.BasicRelief)))
-	b.WriteString(fmt.Sprintf("  Parents: %s\n", currencyFormat(c.ParentRelief)))
-	b.WriteString(fmt.Sprintf("  Spouse: %s\n", currencyFormat(c.SpouseRelief)))
-	b.WriteString(fmt.Sprintf("  Children: %s\n", currencyFormat(c.ChildRelief)))
-	b.WriteString(fmt.Sprintf("  SSB: %s\n", currencyFormat(c.SSBRelief)))
-	b.WriteString(fmt.Sprintf("\nTotal Taxable Income: %s\n", currencyFormat(c.TotalTexable)))
-	b.WriteString(fmt.Sprintf("Total Reliefs: %s\n", currencyFormat(c.TotalRelief)))
-	b.WriteString(fmt.Sprintf("\nTOTAL TAX: %s\n\n", currencyFormat(c.TotalTax)))
-
-	b.WriteString("Tax Breakdown:\n")
-	for _, v := range c.TaxBreakdown {
-		limitStr := "And above"
-		if v.Limit != math.Inf(1) {
-			limitStr = currencyFormat(v.Limit)
-		}
-		b.WriteString(fmt.Sprintf("  %s to %s -> %s\n", currencyFormat(v.Start), limitStr, currencyFormat(v.Amount)))
+	fmt.Fprintf(&b, "%s\n%s\n\n%s: %s\n", title, hint, t(l, "advice_current"), successStyle.Render(currencyFormat(l, a.CurrentTax)))
+	if len(inputs) > 1 {
+		headers, rows := report.AdviceTable(l, a, names)
+		table := lgtable.New().
+			Border(lipgloss.NormalBorder()).
+			BorderStyle(lipgloss.NewStyle().Foreground(themeBorder)).
+			Headers(headers...).
+			Rows(rows...).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				s := lipgloss.NewStyle().Padding(0, 1).Foreground(themeText)
+				if col == len(headers)-3 || col == len(headers)-2 {
+					s = s.Align(lipgloss.Right)
+				}
+				return s
+			})
+		b.WriteString(table.Render() + "\n")
 	}
-	return b.String()
+	b.WriteString("\n")
+	wrap := lipgloss.NewStyle()
+	if m.width > 4 {
+		wrap = wrap.Width(m.width - 4)
+	}
+	for _, note := range report.AdviceNotes(l, a, names) {
+		b.WriteString(wrap.Render("• "+note) + "\n")
+	}
+	return b.String() + "\n" + footer
+}
+
+// --- Calculation Trace ---
+
+// buildExplainView lists the steps of the calculation: what was worked out,
+// how, and the result.
+func buildExplainView(m *model) string {
+	l := m.selectedLang
+	footer := lipgloss.NewStyle().Foreground(themeBorder).Render(t(l, "explain_footer"))
+	title := successStyle.Render(t(l, "explain_title"))
+
+	var rows [][]string
+	for i, s := range report.Explain(l, m.calcResult) {
+		rows = append(rows, []string{i18n.Digits(l, fmt.Sprint(i+1)), s.Label, s.Working, s.Amount})
+	}
+	table := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(themeBorder)).
+		Headers("#", t(l, "explain_step"), t(l, "explain_working"), t(l, "res_amount")).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			s := lipgloss.NewStyle().Padding(0, 1).Foreground(themeText)
+			if col == 0 || col == 3 {
+				s = s.Align(lipgloss.Right)
+			}
+			return s
+		})
+	// Narrow terminals wrap the table rather than cut it off.
+	if m.width > 4 && lipgloss.Width(table.Render()) > m.width-4 {
+		table = table.Width(m.width - 4)
+	}
+	return title + "\n\n" + table.Render() + "\n\n" + footer
+}
+
+// diffStyle colours a difference green when it favours the taxpayer (more
+// relief, less taxable income or tax) and red otherwise.
+func diffStyle(id string, diff float64) lipgloss.Style {
+	s := lipgloss.NewStyle()
+	better := diff > 0
+	switch {
+	case diff == 0:
+		return s.Foreground(themeBorder)
+	case id == "res_gross_income":
+		return s.Foreground(themeSecondary)
+	case id == "res_final_tax" || id == "res_total_income":
+		better = diff < 0
+	}
+	if better {
+		return s.Foreground(themePrimary)
+	}
+	return s.Foreground(themeError)
 }
 
 // --- T020: Export Writers ---
-func exportToFile(format string, c *pitcalc.CalculatePITOutput) error {
-	filename := "PIT_Report." + format
 
-	switch format {
-	case "json":
-		data, err := json.MarshalIndent(c, "", "  ")
+// exportFormatTemplate selects rendering with a built-in or user-provided
+// report template instead of a fixed format.
+const exportFormatTemplate = "template"
+
+// exportExt returns the extension, including the dot, of the file the
+// selected format or template produces.
+func exportExt(format, template string) (string, error) {
+	if format == exportFormatTemplate {
+		tmpl, err := report.ResolveTemplate(strings.TrimSpace(template))
 		if err != nil {
-			return err
+			return "", err
 		}
-		return os.WriteFile(filename, data, 0644)
+		return tmpl.Ext(), nil
+	}
+	f, err := report.ParseFormat(format)
+	if err != nil {
+		return "", err
+	}
+	return "." + string(f), nil
+}
 
-	case "csv":
-		f, err := os.Create(filename)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-
-		w := csv.NewWriter(f)
-		w.Write([]string{"Metric", "Value (MMK)"})
-		w.Write([]string{"Gross Income (Yearly)", fmt.Sprintf("%.2f", c.GrossIncome)})
-		w.Write([]string{"Basic Relief", fmt.Sprintf("%.2f", c.BasicRelief)})
-		w.Write([]string{"Parents Relief", fmt.Sprintf("%.2f", c.ParentRelief)})
-		w.Write([]string{"Spouse Relief", fmt.Sprintf("%.2f", c.SpouseRelief)})
-		w.Write([]string{"Children Relief", fmt.Sprintf("%.2f", c.ChildRelief)})
-		w.Write([]string{"SSB Relief", fmt.Sprintf("%.2f", c.SSBRelief)})
-		w.Write([]string{"Total Taxable Income", fmt.Sprintf("%.2f", c.TotalTexable)})
-		w.Write([]string{"Total Reliefs", fmt.Sprintf("%.2f", c.TotalRelief)})
-		w.Write([]string{"Total Tax", fmt.Sprintf("%.2f", c.TotalTax)})
-		w.Write([]string{"", ""})
-
-		w.Write([]string{"Breakdown From", "Breakdown To", "Tax Amount"})
-		for _, tb := range c.TaxBreakdown {
-			limit := fmt.Sprintf("%.2f", tb.Limit)
-			if tb.Limit == math.Inf(1) {
-				limit = "And above"
-			}
-			w.Write([]string{fmt.Sprintf("%.2f", tb.Start), limit, fmt.Sprintf("%.2f", tb.Amount)})
+// exportPath returns the file the export form's current values write to.
+func (m *model) exportPath() (string, error) {
+	ext, err := exportExt(m.valExportFormat, m.valTemplate)
+	if err != nil {
+		return "", err
+	}
+	vars := report.FilenameVars{Time: m.exportTime, Name: m.valEmployee, Year: m.calcInput.FiscalYear}
+	return report.ExportPath(strings.TrimSpace(m.valExportDir), m.valPattern, vars, ext), nil
+}
+
+// exportExists reports whether exporting would replace an existing file.
+func (m *model) exportExists() bool {
+	path, err := m.exportPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func exportWithTemplate(filename, nameOrPath string, l langKey, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	tmpl, err := report.ResolveTemplate(strings.TrimSpace(nameOrPath))
+	if err != nil {
+		return err
+	}
+	return tmpl.WriteFile(filename, l, in, c)
+}
+
+func exportToFile(filename, format string, l langKey, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	f, err := report.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	return report.WriteFile(filename, f, l, in, c)
+}
+
+func exportComparison(filename, format string, l langKey, scenarios []report.Scenario) error {
+	f, err := report.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	return report.WriteComparisonFile(filename, f, l, scenarios)
+}
+
+// export writes the report chosen in the export form and returns the
+// message to show on the result screen.
+func (m *model) export() string {
+	l := m.selectedLang
+	path, err := m.exportPath()
+	if err != nil {
+		return t(l, "err_export") + err.Error()
+	}
+	if m.exportExists() && !m.valOverwrite {
+		return t(l, "export_cancelled")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return t(l, "err_export") + err.Error()
+	}
+	if m.exportComparison {
+		err = exportComparison(path, m.valExportFormat, l, m.scenarios)
+	} else if m.valExportFormat == exportFormatTemplate {
+		err = exportWithTemplate(path, m.valTemplate, l, m.calcInput, m.calcResult)
+	} else {
+		err = exportToFile(path, m.valExportFormat, l, m.calcInput, m.calcResult)
+	}
+	if err != nil {
+		return t(l, "err_export") + err.Error()
+	}
+	return t(l, "success_export") + path
+}
+
+// formInput builds the calculation input from the tax form's current values.
+// Empty or not yet valid fields count as zero.
+func (m *model) formInput() pitcalc.CalculatePITInput {
+	value := func(s string) float64 {
+		v, err := parseNumericInput(s)
+		if err != nil || v == nil {
+			return 0
 		}
-		w.Flush()
-		return w.Error()
+		return *v
+	}
+
+	var spouse int64
+	if m.valSpouse {
+		spouse = 1
+	}
+	return pitcalc.CalculatePITInput{
+		MonthlyIncome:    value(m.valSalary) + (value(m.valBonus) / 12),
+		StartingMonth:    m.valStartMonth,
+		EndingMonth:      m.valEndMonth,
+		FiscalYear:       fiscalYear(m.valFiscalYear),
+		DependentParents: int64(value(m.valParents)),
+		DependentSpouse:  spouse,
+		Childrens:        int64(value(m.valChildren)),
+		SSB:              value(m.valSSB),
+		Dependents:       m.dependents,
+	}
+}
+
+// calculate works out the tax on the form's values and moves to the result
+// screen. A calculation that fails leaves no result behind, so the result
+// keys cannot act on an earlier one.
+func (m *model) calculate() {
+	m.state = stateResult
+	input := m.formInput()
+	output, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		m.errMessage = report.ErrorMessage(m.selectedLang, err)
+		m.calcInput = pitcalc.CalculatePITInput{}
+		m.calcResult = nil
+		return
+	}
+	m.errMessage = ""
+	m.calcInput = input
+	m.calcResult = output
+	m.history = append(m.history, calculation{Input: input, Output: output})
+	m.recordHistory()
+	m.viewport.SetContent(buildResultView(m))
+}
+
+// --- Live Recalculation Pane ---
+
+// previewWidth is the width of the live estimate pane beside the tax form.
+const previewWidth = 44
+
+// buildLivePreview renders the tax summary shown while the form is edited.
+func buildLivePreview(l langKey, in pitcalc.CalculatePITInput) string {
+	var body string
+	c, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		body = errorStyle.Render(previewError(l, err))
+	} else {
+		body = fmt.Sprintf("%s: %s\n%s: %s\n%s: %s\n\n%s:\n%s",
+			t(l, "res_gross_income"), currencyFormat(l, c.GrossIncome),
+			t(l, "res_total_reliefs"), currencyFormat(l, c.TotalRelief),
+			t(l, "res_total_income"), currencyFormat(l, c.TotalTexable),
+			t(l, "res_final_tax"), successStyle.Render(currencyFormat(l, c.TotalTax)))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(themeSecondary).
+		Padding(1, 2).
+		Width(previewWidth).
+		Render(successStyle.Render(t(l, "live_preview")) + "\n\n" + body)
+}
 
-	default: // txt
-		return os.WriteFile(filename, []byte(generatePlainTextReport(c)), 0644)
+// previewError explains in the UI language why the engine rejected the
+// form's values, with a hint in place of an error while no salary is
+// entered.
+func previewError(l langKey, err error) string {
+	if errors.Is(err, pitcalc.ErrIncome) {
+		return t(l, "preview_no_income")
 	}
+	return report.ErrorMessage(l, err)
+}
+
+// formView renders the tax form with the live estimate beside it, or below
+// it when the terminal is too narrow for both.
+func (m *model) formView() string {
+	form := m.taxForm.View()
+	preview := buildLivePreview(m.selectedLang, m.formInput())
+	if m.width > 0 && lipgloss.Width(form)+lipgloss.Width(preview)+2 > m.width {
+		return lipgloss.JoinVertical(lipgloss.Left, form, preview)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, form, "  ", preview)
 }
 
 func (m *model) Init() tea.Cmd {
+	switch m.state {
+	case stateProfile:
+		return m.profileForm.Init()
+	case stateForm:
+		return m.taxForm.Init()
+	}
 	return m.langForm.Init()
 }
 
@@ -506,21 +1080,75 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if msg.String() == "q" {
 				return m, tea.Quit
 			}
-			if msg.String() == "c" {
-				err := clipboard.WriteAll(generatePlainTextReport(m.calcResult))
+			if msg.String() == "c" && m.calcResult != nil {
+				err := clipboard.WriteAll(report.PlainText(m.calcInput, m.calcResult))
 				if err != nil {
-					m.actionAlert = "Failed to copy"
+					m.actionAlert = t(m.selectedLang, "err_copy")
 				} else {
 					m.actionAlert = t(m.selectedLang, "success_copy")
 				}
 				m.viewport.SetContent(buildResultView(m))
 				return m, nil
 			}
-			if msg.String() == "e" {
+			if msg.String() == "e" && m.calcResult != nil {
 				m.state = stateExport
+				m.exportComparison = false
 				m.initExportForm()
 				return m, nil
 			}
+			if msg.String() == "s" && m.calcResult != nil {
+				m.state = stateScenario
+				m.initScenarioForm()
+				return m, nil
+			}
+			if msg.String() == "h" {
+				m.openHistory()
+				return m, nil
+			}
+			if msg.String() == "u" {
+				m.toggleUnits()
+				m.viewport.SetContent(buildResultView(m))
+				return m, nil
+			}
+			if msg.String() == "l" {
+				m.state = stateLang
+				m.actionAlert = ""
+				m.initLangForm()
+				return m, nil
+			}
+			if msg.String() == "p" && m.calcResult != nil {
+				m.state = stateSaveProfile
+				m.initSaveProfileForm()
+				return m, nil
+			}
+			if msg.String() == "v" {
+				m.state = stateCompare
+				m.actionAlert = ""
+				return m, nil
+			}
+			if msg.String() == "a" && m.calcResult != nil {
+				m.state = stateAdvice
+				m.actionAlert = ""
+				if m.advicePartner > len(m.scenarios) {
+					m.advicePartner = 0
+				}
+				return m, nil
+			}
+			if msg.String() == "?" && m.calcResult != nil {
+				m.state = stateExplain
+				m.actionAlert = ""
+				return m, nil
+			}
+			if msg.String() == "r" || msg.String() == "n" {
+				if msg.String() == "n" {
+					m.resetTaxValues()
+				}
+				m.state = stateForm
+				m.errMessage = ""
+				m.actionAlert = ""
+				m.initTaxForm()
+				return m, nil
+			}
 
 			// Viewport navigation
 			var cmd tea.Cmd
@@ -528,9 +1156,66 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.state == stateHistory {
+			if msg.String() == "q" {
+				return m, tea.Quit
+			}
+			m.updateHistory(msg.String())
+			return m, nil
+		}
+
+		if m.state == stateAdvice {
+			switch msg.String() {
+			case "q":
+				return m, tea.Quit
+			case "b", "esc":
+				m.state = stateResult
+				m.viewport.SetContent(buildResultView(m))
+			case "tab":
+				m.advicePartner = (m.advicePartner + 1) % (len(m.scenarios) + 1)
+			}
+			return m, nil
+		}
+
+		if m.state == stateExplain {
+			switch msg.String() {
+			case "q":
+				return m, tea.Quit
+			case "b", "esc":
+				m.state = stateResult
+				m.viewport.SetContent(buildResultView(m))
+			}
+			return m, nil
+		}
+
+		if m.state == stateCompare {
+			switch msg.String() {
+			case "q":
+				return m, tea.Quit
+			case "b", "esc":
+				m.state = stateResult
+				m.viewport.SetContent(buildResultView(m))
+			case "d":
+				if len(m.scenarios) > 0 {
+					m.scenarios = m.scenarios[:len(m.scenarios)-1]
+				}
+			case "e":
+				if len(m.scenarios) > 0 {
+					m.state = stateExport
+					m.exportComparison = true
+					m.initExportForm()
+				}
+			}
+			return m, nil
+		}
+
 	case tea.WindowSizeMsg:
+		m.width = msg.Width
 		m.viewport.Width = msg.Width - 4
 		m.viewport.Height = msg.Height - 10
+		if m.calcResult != nil {
+			m.viewport.SetContent(buildResultView(m))
+		}
 		return m, nil
 	}
 
@@ -541,12 +1226,42 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.langForm = f
 		}
 		if m.langForm.State == huh.StateCompleted {
+			m.saveLang()
+			if m.calcResult != nil {
+				m.state = stateResult
+				m.viewport.SetContent(buildResultView(m))
+				return m, nil
+			}
 			m.state = stateForm
 			m.initTaxForm()
 			return m, nil
 		}
 		return m, cmd
 
+	case stateProfile:
+		form, cmd := m.profileForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.profileForm = f
+		}
+		if m.profileForm.State == huh.StateCompleted {
+			m.chooseProfile()
+			return m, nil
+		}
+		return m, cmd
+
+	case stateSaveProfile:
+		form, cmd := m.profileForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.profileForm = f
+		}
+		if m.profileForm.State == huh.StateCompleted {
+			m.state = stateResult
+			m.actionAlert = m.saveProfile()
+			m.viewport.SetContent(buildResultView(m))
+			return m, nil
+		}
+		return m, cmd
+
 	case stateForm:
 		form, cmd := m.taxForm.Update(msg)
 		if f, ok := form.(*huh.Form); ok {
@@ -554,54 +1269,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if m.taxForm.State == huh.StateCompleted {
-			m.state = stateResult
-
-			salary, _ := parseNumericInput(m.valSalary)
-			bonus, _ := parseNumericInput(m.valBonus)
-			ssb, _ := parseNumericInput(m.valSSB)
-			children, _ := parseNumericInput(m.valChildren)
-			parents, _ := parseNumericInput(m.valParents)
-
-			rawSalary := 0.0
-			if salary != nil {
-				rawSalary = *salary
-			}
-			rawBonus := 0.0
-			if bonus != nil {
-				rawBonus = *bonus
-			}
-			rawSSB := 0.0
-			if ssb != nil {
-				rawSSB = *ssb
-			}
-			rawChildren := 0.0
-			if children != nil {
-				rawChildren = *children
-			}
-			rawParents := 0.0
-			if parents != nil {
-				rawParents = *parents
-			}
-
-			output, err := pitcalc.CalculatePIT(pitcalc.CalculatePITInput{
-				MonthlyIncome:    rawSalary + (rawBonus / 12),
-				StartingMonth:    4,
-				DependentParents: int64(rawParents),
-				DependentSpouse: func() int64 {
-					if m.valSpouse {
-						return 1
-					}
-					return 0
-				}(),
-				Childrens: int64(rawChildren),
-				SSB:       rawSSB,
-			})
-			if err != nil {
-				m.errMessage = err.Error()
-			} else {
-				m.calcResult = output
-				m.viewport.SetContent(buildResultView(m))
-			}
+			m.calculate()
 			return m, nil
 		}
 		return m, cmd
@@ -613,11 +1281,29 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.exportForm.State == huh.StateCompleted {
 			m.state = stateResult
-			err := exportToFile(m.valExportFormat, m.calcResult)
-			if err != nil {
-				m.actionAlert = "Export failed: " + err.Error()
-			} else {
-				m.actionAlert = t(m.selectedLang, "success_export")
+			if m.exportComparison {
+				m.state = stateCompare
+			}
+			m.actionAlert = m.export()
+			m.viewport.SetContent(buildResultView(m))
+			return m, nil
+		}
+		return m, cmd
+
+	case stateScenario:
+		form, cmd := m.scenarioForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.scenarioForm = f
+		}
+		if m.scenarioForm.State == huh.StateCompleted {
+			m.state = stateResult
+			name := strings.TrimSpace(m.valScenario)
+			m.scenarios = append(m.scenarios, report.Scenario{Name: name, Input: m.calcInput, Output: m.calcResult})
+			m.actionAlert = t(m.selectedLang, "success_scenario") + name
+			if m.historyStore != nil && m.historyID != 0 {
+				if err := m.historyStore.SetLabel(m.historyID, name); err != nil {
+					m.actionAlert = t(m.selectedLang, "err_history") + err.Error()
+				}
 			}
 			m.viewport.SetContent(buildResultView(m))
 			return m, nil
@@ -635,15 +1321,34 @@ func (m *model) View() string {
 	case stateLang:
 		return "\n" + banner + "\n\n" + m.langForm.View()
 
+	case stateProfile, stateSaveProfile:
+		return "\n" + banner + "\n\n" + m.profileForm.View()
+
 	case stateForm:
-		return "\n" + banner + "\n\n" + m.taxForm.View()
+		return "\n" + banner + "\n\n" + m.formView()
 
 	case stateExport:
 		return "\n" + banner + "\n\n" + m.exportForm.View()
 
+	case stateScenario:
+		return "\n" + banner + "\n\n" + m.scenarioForm.View()
+
+	case stateCompare:
+		return "\n" + banner + "\n\n" + buildCompareView(m)
+
+	case stateAdvice:
+		return "\n" + banner + "\n\n" + buildAdviceView(m)
+
+	case stateExplain:
+		return "\n" + banner + "\n\n" + buildExplainView(m)
+
+	case stateHistory:
+		return "\n" + banner + "\n\n" + buildHistoryBrowser(m)
+
 	case stateResult:
 		if m.errMessage != "" {
-			return "\n" + banner + "\n\n" + errorStyle.Render("Error: "+m.errMessage)
+			footer := lipgloss.NewStyle().Foreground(themeBorder).Render(t(m.selectedLang, "error_footer"))
+			return "\n" + banner + "\n\n" + errorStyle.Render(t(m.selectedLang, "err_prefix")+m.errMessage) + "\n\n" + footer
 		}
 		if m.calcResult != nil {
 			return "\n" + banner + "\n\n" + m.viewport.View()
@@ -654,7 +1359,18 @@ func (m *model) View() string {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
+	lang := flag.String("lang", "", "display language (EN, MY or a language file's code); overrides the saved preference and the locale")
+	flag.Parse()
+	// A broken language file only loses that language.
+	if err := i18n.LoadUserDir(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+	if _, ok := i18n.ParseLang(*lang); *lang != "" && !ok {
+		fmt.Fprintf(os.Stderr, "unsupported language %q\n", *lang)
+		os.Exit(2)
+	}
+
+	p := tea.NewProgram(initialModel(*lang))
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
 	}
@@ -1,8 +1,18 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/report"
 )
 
 func TestCurrencyFormat(t *testing.T) {
@@ -50,7 +60,7 @@ func TestCurrencyFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := currencyFormat(tt.amount)
+			result := currencyFormat(langEN, tt.amount)
 			if result != tt.expected {
 				t.Errorf("expected %q, got %q", tt.expected, result)
 			}
@@ -137,6 +147,36 @@ func TestParseNumericInput(t *testing.T) {
 			expectedValue: 72000,
 			expectedError: false,
 		},
+		{
+			name:          "myanmar digits",
+			input:         "၅၀၀,၀၀၀",
+			expectedValue: 500000,
+			expectedError: false,
+		},
+		{
+			name:          "lakh in Burmese",
+			input:         "၅ သိန်း",
+			expectedValue: 500000,
+			expectedError: false,
+		},
+		{
+			name:          "lakh in English",
+			input:         "12.5 lakh",
+			expectedValue: 1250000,
+			expectedError: false,
+		},
+		{
+			name:          "k shorthand",
+			input:         "72k",
+			expectedValue: 72000,
+			expectedError: false,
+		},
+		{
+			name:          "unknown unit",
+			input:         "5 dollars",
+			expectedValue: 0,
+			expectedError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -161,7 +201,7 @@ func TestParseNumericInput(t *testing.T) {
 }
 
 func TestCurrencyFormatContainsMMK(t *testing.T) {
-	result := currencyFormat(12345.67)
+	result := currencyFormat(langEN, 12345.67)
 	if !strings.Contains(result, "MMK") {
 		t.Errorf("expected format to contain 'MMK', got %q", result)
 	}
@@ -192,7 +232,7 @@ func TestCurrencyFormatTwoDecimalPlaces(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := currencyFormat(tt.amount)
+			result := currencyFormat(langEN, tt.amount)
 			if result != tt.expected {
 				t.Errorf("expected %q, got %q", tt.expected, result)
 			}
@@ -257,3 +297,670 @@ func TestParseNumericInputWithCommas(t *testing.T) {
 		})
 	}
 }
+
+func TestFormInput(t *testing.T) {
+	m := &model{
+		valStartMonth: 7,
+		valEndMonth:   12,
+		valSalary:     "1,000,000",
+		valBonus:      "1200000",
+		valSpouse:     true,
+		valChildren:   "2",
+		valParents:    "abc",
+		valSSB:        "72000",
+	}
+
+	in := m.formInput()
+	if in.MonthlyIncome != 1100000 {
+		t.Errorf("expected monthly income 1100000, got %f", in.MonthlyIncome)
+	}
+	if in.DependentSpouse != 1 || in.Childrens != 2 {
+		t.Errorf("expected spouse 1 and 2 children, got %d and %d", in.DependentSpouse, in.Childrens)
+	}
+	if in.DependentParents != 0 {
+		t.Errorf("expected invalid parents to count as 0, got %d", in.DependentParents)
+	}
+	if in.StartingMonth != 7 || in.EndingMonth != 12 {
+		t.Errorf("expected months 7 to 12, got %d to %d", in.StartingMonth, in.EndingMonth)
+	}
+	if in.SSB != 72000 {
+		t.Errorf("expected SSB 72000, got %f", in.SSB)
+	}
+}
+
+func TestBuildLivePreview(t *testing.T) {
+	m := &model{selectedLang: langEN, valSalary: "1000000", valStartMonth: 4}
+	preview := buildLivePreview(langEN, m.formInput())
+	if !strings.Contains(preview, "Live Estimate") {
+		t.Errorf("expected preview title, got %q", preview)
+	}
+
+	before := preview
+	m.valChildren = "1"
+	if after := buildLivePreview(langEN, m.formInput()); after == before {
+		t.Errorf("expected preview to change when a child is added")
+	}
+
+	// The engine's errors are shown in the UI language.
+	tests := []struct {
+		name     string
+		in       pitcalc.CalculatePITInput
+		expected string
+	}{
+		{"no income", pitcalc.CalculatePITInput{StartingMonth: 4}, i18n.T(langMY, "preview_no_income")},
+		{"end before start", pitcalc.CalculatePITInput{MonthlyIncome: 1, StartingMonth: 10, EndingMonth: 9}, i18n.T(langMY, "err_end_month")},
+		{"too many parents", pitcalc.CalculatePITInput{MonthlyIncome: 1, StartingMonth: 4, DependentParents: 3}, i18n.Tf(langMY, "calc_parents_max", 2)},
+	}
+	for _, tt := range tests {
+		_, err := pitcalc.CalculatePIT(tt.in)
+		if err == nil {
+			t.Fatalf("%s: expected the engine to reject the input", tt.name)
+		}
+		if got := previewError(langMY, err); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestResultKeys_EditAndNew(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		expectedSalary string
+	}{
+		{"edit keeps values", "r", "1000000"},
+		{"new clears values", "n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.EnvDir, t.TempDir())
+			m := initialModel("")
+			m.selectedLang = langMY
+			m.valSalary = "1000000"
+			m.state = stateResult
+			m.calcResult = &pitcalc.CalculatePITOutput{}
+			m.history = []calculation{{Output: m.calcResult}}
+
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+
+			if m.state != stateForm {
+				t.Errorf("expected form state, got %d", m.state)
+			}
+			if m.selectedLang != langMY {
+				t.Errorf("expected language to be kept, got %q", m.selectedLang)
+			}
+			if m.valSalary != tt.expectedSalary {
+				t.Errorf("expected salary %q, got %q", tt.expectedSalary, m.valSalary)
+			}
+			if len(m.history) != 1 {
+				t.Errorf("expected history to be kept, got %d entries", len(m.history))
+			}
+		})
+	}
+}
+
+func TestResultKeys_Scroll(t *testing.T) {
+	t.Setenv(config.EnvDir, t.TempDir())
+	m := initialModel("")
+	m.viewport.Width, m.viewport.Height = 20, 2
+	m.viewport.SetContent(strings.Repeat("line\n", 20))
+	m.state = stateResult
+	m.calcResult = &pitcalc.CalculatePITOutput{}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if m.viewport.YOffset == 0 {
+		t.Error("expected ctrl+d to scroll down")
+	}
+	offset := m.viewport.YOffset
+	units := i18n.TraditionalUnits()
+	t.Cleanup(func() { i18n.SetTraditionalUnits(units) })
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if m.viewport.YOffset != offset {
+		t.Errorf("expected u not to scroll, got offset %d", m.viewport.YOffset)
+	}
+	if i18n.TraditionalUnits() == units {
+		t.Error("expected u to toggle the units")
+	}
+}
+
+func TestResultKeys_AfterError(t *testing.T) {
+	for _, key := range []string{"c", "e", "s", "p", "a", "?"} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(config.EnvDir, t.TempDir())
+			m := initialModel("")
+			m.valSalary = "1000000"
+			m.calculate()
+			if m.calcResult == nil {
+				t.Fatalf("expected a result, got error %q", m.errMessage)
+			}
+
+			m.valSalary = ""
+			m.calculate()
+			if m.errMessage == "" {
+				t.Fatal("expected the calculation to fail")
+			}
+			if m.calcResult != nil {
+				t.Error("expected the earlier result to be cleared")
+			}
+			m.actionAlert = ""
+
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+
+			if m.state != stateResult {
+				t.Errorf("expected to stay on the result screen, got state %d", m.state)
+			}
+			if m.actionAlert != "" {
+				t.Errorf("expected no action, got %q", m.actionAlert)
+			}
+		})
+	}
+}
+
+func TestBuildHistoryString(t *testing.T) {
+	history := []calculation{
+		{Output: &pitcalc.CalculatePITOutput{GrossIncome: 1000, TotalTax: 10}},
+		{Output: &pitcalc.CalculatePITOutput{GrossIncome: 2000, TotalTax: 20}},
+	}
+	if s := buildHistoryString(langEN, history[:1]); s != "" {
+		t.Errorf("expected no history for a single calculation, got %q", s)
+	}
+	s := buildHistoryString(langEN, history)
+	if !strings.Contains(s, "#1") || strings.Contains(s, "#2") {
+		t.Errorf("expected only the earlier calculation, got %q", s)
+	}
+}
+
+func TestBuildCompareView(t *testing.T) {
+	m := &model{selectedLang: langEN}
+	if view := buildCompareView(m); !strings.Contains(view, "No scenarios") {
+		t.Errorf("expected empty comparison hint, got %q", view)
+	}
+
+	for _, name := range []string{"Claim parents", "Spouse claims parents"} {
+		m.scenarios = append(m.scenarios, report.Scenario{
+			Name:   name,
+			Output: &pitcalc.CalculatePITOutput{TotalTax: float64(len(m.scenarios))},
+		})
+	}
+	view := buildCompareView(m)
+	for _, want := range []string{"Claim parents", "Spouse claims parents", "+1.00 MMK"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected comparison to contain %q, got %q", want, view)
+		}
+	}
+}
+
+func TestBuildAdviceView(t *testing.T) {
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := &model{selectedLang: langEN, state: stateResult, calcInput: in, calcResult: out}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.state != stateAdvice {
+		t.Fatalf("expected the advisor page, got state %d", m.state)
+	}
+	view := buildAdviceView(m)
+	for _, want := range []string{"Save a scenario", "You: SSB is already at the limit."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the advice to contain %q, got %q", want, view)
+		}
+	}
+
+	spouse := pitcalc.CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, DependentParents: 2, Childrens: 1}
+	m.scenarios = []report.Scenario{{Name: "Spouse", Input: spouse}}
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view = buildAdviceView(m)
+	for _, want := range []string{"Paired with scenario: Spouse", "2 parents, 1 child", "saves 125,000.00 MMK a year"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the advice to contain %q, got %q", want, view)
+		}
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.advicePartner != 0 {
+		t.Errorf("expected tab to cycle back to no partner, got %d", m.advicePartner)
+	}
+}
+
+func TestBuildExplainView(t *testing.T) {
+	tests := []struct {
+		lang     langKey
+		expected []string
+	}{
+		{langEN, []string{"How was this calculated?", "20% of 12,000,000.00 MMK, at most 10,000,000.00 MMK", "5% of 5,528,000.00 MMK"}},
+		{langMY, []string{"မည်သို့ တွက်ချက်ထားသနည်း?", "၅% နှုန်း၊ ၅,၅၂၈,၀၀၀.၀၀ ကျပ် ပေါ်တွင်"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4, DependentParents: 2, SSB: 72000}
+			out, err := pitcalc.CalculatePIT(in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			m := &model{selectedLang: tt.lang, state: stateResult, calcInput: in, calcResult: out}
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+			if m.state != stateExplain {
+				t.Fatalf("expected the explanation page, got state %d", m.state)
+			}
+			view := buildExplainView(m)
+			for _, want := range tt.expected {
+				if !strings.Contains(view, want) {
+					t.Errorf("expected the explanation to contain %q, got %q", want, view)
+				}
+			}
+			m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+			if m.state != stateResult {
+				t.Errorf("expected esc to return to the result, got state %d", m.state)
+			}
+		})
+	}
+}
+
+func TestExportOptions_Comparison(t *testing.T) {
+	m := &model{valExportFormat: "pdf", exportComparison: true}
+	opts := m.exportOptions()
+	if len(opts) != len(report.ComparisonFormats()) {
+		t.Errorf("expected %d options, got %d", len(report.ComparisonFormats()), len(opts))
+	}
+	if m.valExportFormat != "txt" {
+		t.Errorf("expected unsupported format to fall back to txt, got %q", m.valExportFormat)
+	}
+}
+
+func TestExport_Overwrite(t *testing.T) {
+	// The export directory does not exist yet and is created on export.
+	dir := filepath.Join(t.TempDir(), "reports", "2026")
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := &model{
+		selectedLang:    langEN,
+		calcInput:       in,
+		calcResult:      out,
+		valExportFormat: "txt",
+		valExportDir:    dir,
+		valPattern:      "PIT_{name}",
+		valEmployee:     "Aung Aung",
+	}
+	m.initExportForm()
+	if m.exportExists() {
+		t.Fatal("expected no existing file before the first export")
+	}
+	if msg := m.export(); !strings.HasSuffix(msg, "PIT_Aung_Aung.txt") {
+		t.Fatalf("expected export path in message, got %q", msg)
+	}
+	if !m.exportExists() {
+		t.Fatal("expected the exported file to exist")
+	}
+	if msg := m.export(); !strings.Contains(msg, "cancelled") {
+		t.Errorf("expected export to be cancelled without confirmation, got %q", msg)
+	}
+	m.valOverwrite = true
+	if msg := m.export(); !strings.HasSuffix(msg, "PIT_Aung_Aung.txt") {
+		t.Errorf("expected confirmed overwrite to succeed, got %q", msg)
+	}
+}
+
+func TestBuildResultView_MonthsCounted(t *testing.T) {
+	tests := []struct {
+		lang     langKey
+		expected string
+	}{
+		{langEN, "9 (July – March)"},
+		{langMY, "၉ (ဇူလိုင် – မတ်)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 7}
+			out, err := pitcalc.CalculatePIT(in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			m := &model{selectedLang: tt.lang, calcInput: in, calcResult: out}
+			if view := buildResultView(m); !strings.Contains(view, tt.expected) {
+				t.Errorf("expected result view to contain %q, got %q", tt.expected, view)
+			}
+		})
+	}
+}
+
+func TestBuildResultView_Dependents(t *testing.T) {
+	m := &model{selectedLang: langEN, dependents: []pitcalc.Dependent{
+		{Name: "Daw Mya", Relationship: pitcalc.RelationParent, LivesWith: true},
+		{Name: "U Ba", Relationship: pitcalc.RelationParent},
+	}}
+	m.valSalary = "1000000"
+	m.valStartMonth, m.valEndMonth = 4, 3
+	m.calcInput = m.formInput()
+	out, err := pitcalc.CalculatePIT(m.calcInput)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.calcResult = out
+	view := buildResultView(m)
+	for _, want := range []string{"Daw Mya (Parent)", "Accepted: lives with the taxpayer", "Rejected: does not live with the taxpayer"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected result view to contain %q, got %q", want, view)
+		}
+	}
+}
+
+func TestValidateEndMonth(t *testing.T) {
+	tests := []struct {
+		year      string
+		start     int64
+		end       int64
+		shouldErr bool
+	}{
+		{"", 10, 10, false},
+		{"", 10, 3, false},
+		{"", 10, 12, false},
+		{"", 10, 9, true},
+		{"", 10, 4, true},
+		{"2019-20", 10, 9, false},
+		{"2019-20", 10, 3, false},
+		{"2019-20", 4, 3, true},
+		{"2018", 4, 9, false},
+		{"2018", 4, 3, true},
+	}
+	for _, tt := range tests {
+		err := validateEndMonth(langEN, &tt.year, &tt.start)(tt.end)
+		if (err != nil) != tt.shouldErr {
+			t.Errorf("year %q, months %d–%d: expected error %v, got %v", tt.year, tt.start, tt.end, tt.shouldErr, err)
+		}
+	}
+	year := "2021-22"
+	if err := validateStartMonth(langEN, &year)(4); err == nil {
+		t.Error("expected April to be outside the October 2021–March 2022 interim year")
+	}
+}
+
+func TestValidateSSBAndParents(t *testing.T) {
+	tests := []struct {
+		validate func(langKey) func(string) error
+		value    string
+		expected string
+	}{
+		{validateSSB, "360,000", ""},
+		{validateSSB, "360001", "Maximum SSB is 360,000"},
+		{validateParents, "2", ""},
+		{validateParents, "3", "Parents must be 0, 1, or 2"},
+	}
+	for _, tt := range tests {
+		got := ""
+		if err := tt.validate(langEN)(tt.value); err != nil {
+			got = err.Error()
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		total    float64
+		width    int
+		expected string
+	}{
+		{"full", 10, 10, 4, "████"},
+		{"half", 5, 10, 4, "██"},
+		{"partial cell", 1, 8, 2, "▎"},
+		{"zero", 0, 10, 4, ""},
+		{"no total", 5, 0, 4, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := bar(tt.value, tt.total, tt.width); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if result := sparkline([]float64{0, 7, 14}); result != "▁▄█" {
+		t.Errorf("expected %q, got %q", "▁▄█", result)
+	}
+	if result := sparkline([]float64{3, 3}); result != "▁▁" {
+		t.Errorf("expected flat sparkline, got %q", result)
+	}
+}
+
+func TestCharts_AdaptToWidth(t *testing.T) {
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 5000000, StartingMonth: 4}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, width := range []int{60, 120} {
+		for _, chart := range []string{buildBracketChart(langEN, out, width), buildSensitivityChart(langEN, in, width)} {
+			for _, line := range strings.Split(strings.TrimRight(chart, "\n"), "\n") {
+				if w := lipgloss.Width(line); w > width {
+					t.Errorf("width %d: line exceeds width (%d): %q", width, w, line)
+				}
+			}
+		}
+	}
+
+	taxes, rates, current := sensitivityPoints(in, 21)
+	if len(taxes) != 21 || len(rates) != 21 {
+		t.Fatalf("expected 21 points, got %d and %d", len(taxes), len(rates))
+	}
+	if current != 10 {
+		t.Errorf("expected current income at the middle point, got %d", current)
+	}
+	if taxes[0] >= taxes[20] || rates[0] >= rates[20] {
+		t.Errorf("expected tax and effective rate to rise with income")
+	}
+}
+
+func TestHistoryBrowser(t *testing.T) {
+	t.Setenv(config.EnvDir, t.TempDir())
+	m := initialModel("")
+	for _, income := range []float64{1000000, 2000000} {
+		in := pitcalc.CalculatePITInput{MonthlyIncome: income, StartingMonth: 4, Childrens: 1}
+		out, err := pitcalc.CalculatePIT(in)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		m.calcInput, m.calcResult = in, out
+		m.recordHistory()
+	}
+	if m.historyID != 2 {
+		t.Fatalf("expected second entry to be current, got %d", m.historyID)
+	}
+
+	m.state = stateResult
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if m.state != stateHistory || len(m.savedHistory) != 2 {
+		t.Fatalf("expected history browser with 2 entries, got state %d and %d entries", m.state, len(m.savedHistory))
+	}
+	if view := buildHistoryBrowser(m); !strings.Contains(view, "#2") || !strings.Contains(view, "#1") {
+		t.Errorf("expected both entries listed, got %q", view)
+	}
+
+	// Entries are listed newest first; move to the older one and reopen it.
+	m.updateHistory("down")
+	m.updateHistory("enter")
+	if m.state != stateResult || m.calcInput.MonthlyIncome != 1000000 {
+		t.Errorf("expected entry #1 reopened, got state %d and income %f", m.state, m.calcInput.MonthlyIncome)
+	}
+	if m.valSalary != "1000000" || m.valChildren != "1" {
+		t.Errorf("expected form values restored, got salary %q and children %q", m.valSalary, m.valChildren)
+	}
+
+	m.openHistory()
+	m.updateHistory("x")
+	if len(m.savedHistory) != 1 {
+		t.Errorf("expected one entry after delete, got %d", len(m.savedHistory))
+	}
+	entries, _ := m.historyStore.List()
+	if len(entries) != 1 || entries[0].ID != 1 {
+		t.Errorf("expected entry #2 deleted from the store, got %+v", entries)
+	}
+
+	m.updateHistory("d")
+	if m.state != stateForm || m.valSalary != "1000000" {
+		t.Errorf("expected duplicate to open a prefilled form, got state %d and salary %q", m.state, m.valSalary)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	t.Setenv(config.EnvDir, t.TempDir())
+	t.Setenv("LC_ALL", "C")
+	m := initialModel("")
+	if m.state != stateLang {
+		t.Fatalf("expected language screen without profiles, got state %d", m.state)
+	}
+
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4, DependentParents: 2, DependentSpouse: 1, SSB: 72000}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.selectedLang = langMY
+	m.calcInput, m.calcResult = in, out
+	m.state = stateResult
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if m.state != stateSaveProfile {
+		t.Fatalf("expected save profile form, got state %d", m.state)
+	}
+	m.valProfile = " Daw Mya "
+	m.valEmployeeID = "E-42"
+	if msg := m.saveProfile(); !strings.Contains(msg, "Daw Mya") {
+		t.Errorf("expected success message, got %q", msg)
+	}
+
+	m = initialModel("")
+	if m.state != stateProfile {
+		t.Fatalf("expected profile screen, got state %d", m.state)
+	}
+	m.valProfile = "Daw Mya"
+	m.chooseProfile()
+	if m.state != stateForm || m.selectedLang != langMY {
+		t.Errorf("expected form in Myanmar, got state %d and language %q", m.state, m.selectedLang)
+	}
+	if m.valParents != "2" || !m.valSpouse || m.valSSB != "72000" || m.valEmployee != "Daw Mya" {
+		t.Errorf("expected prefilled values, got parents %q, spouse %t, SSB %q, employee %q",
+			m.valParents, m.valSpouse, m.valSSB, m.valEmployee)
+	}
+	if m.profile.EmployeeID != "E-42" {
+		t.Errorf("expected employee ID %q, got %q", "E-42", m.profile.EmployeeID)
+	}
+
+	m = initialModel("")
+	m.valProfile = ""
+	m.chooseProfile()
+	if m.state != stateLang || m.valParents != "" {
+		t.Errorf("expected no profile to leave the form empty, got state %d and parents %q", m.state, m.valParents)
+	}
+}
+
+func TestLanguagePreference(t *testing.T) {
+	t.Setenv(config.EnvDir, t.TempDir())
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "C.UTF-8")
+
+	m := initialModel("")
+	if m.state != stateLang {
+		t.Fatalf("expected language screen with an unknown locale, got state %d", m.state)
+	}
+	m.selectedLang = langMY
+	m.saveLang()
+
+	m = initialModel("")
+	if m.state != stateForm || m.selectedLang != langMY {
+		t.Errorf("expected saved preference to skip the language screen, got state %d and language %q", m.state, m.selectedLang)
+	}
+	m = initialModel("en")
+	if m.selectedLang != langEN {
+		t.Errorf("expected --lang to override the saved preference, got %q", m.selectedLang)
+	}
+
+	t.Setenv(config.EnvDir, t.TempDir())
+	t.Setenv("LANG", "my_MM.UTF-8")
+	m = initialModel("")
+	if m.state != stateForm || m.selectedLang != langMY {
+		t.Errorf("expected locale to select Myanmar, got state %d and language %q", m.state, m.selectedLang)
+	}
+
+	// The language can be changed from the result screen.
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.calcInput, m.calcResult = in, out
+	m.state = stateResult
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if m.state != stateLang {
+		t.Errorf("expected language screen, got state %d", m.state)
+	}
+}
+
+func TestToggleUnits(t *testing.T) {
+	t.Setenv(config.EnvDir, t.TempDir())
+	m := initialModel("MY")
+	defer i18n.SetTraditionalUnits(false)
+
+	in := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.calcInput, m.calcResult = in, out
+	m.state = stateResult
+	if view := buildResultView(m); !strings.Contains(view, "၁၂,၀၀၀,၀၀၀.၀၀ ကျပ်") {
+		t.Errorf("expected Myanmar digits with separators, got %q", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if view := buildResultView(m); !strings.Contains(view, "၁ ကုဋေ ၂၀ သိန်း") {
+		t.Errorf("expected lakh units after toggling, got %q", view)
+	}
+	if cfg, err := config.Load(); err != nil || !cfg.MyanmarUnits {
+		t.Errorf("expected the units setting to be saved, got %+v, %v", cfg, err)
+	}
+
+	i18n.SetTraditionalUnits(false)
+	if m = initialModel("MY"); !i18n.TraditionalUnits() {
+		t.Error("expected the saved units setting to be restored")
+	}
+}
+
+func TestLangForm_LoadedLanguages(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvDir, dir)
+	t.Setenv("LC_ALL", "C")
+	if err := os.MkdirAll(filepath.Join(dir, "lang"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"lang": "mnw", "name": "ဘာသာမန်", "messages": {"title": "Mon title"}}`
+	if err := os.WriteFile(filepath.Join(dir, "lang", "mnw.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := i18n.LoadUserDir(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := initialModel("")
+	if m.state != stateLang {
+		t.Fatalf("expected language screen, got state %d", m.state)
+	}
+	if view := m.View(); !strings.Contains(view, "ဘာသာမန်") {
+		t.Errorf("expected the loaded language to be offered, got %q", view)
+	}
+	if m = initialModel("mnw"); m.state != stateForm || m.selectedLang != "MNW" {
+		t.Errorf("expected --lang to select the loaded language, got state %d and language %q", m.state, m.selectedLang)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/myanmar-pit-calculator/pkg/profile"
+)
+
+// --- Saved Profiles ---
+
+// bilingual joins the English and Myanmar text for screens shown before a
+// language is chosen.
+func bilingual(id string) string {
+	return t(langEN, id) + " / " + t(langMY, id)
+}
+
+// loadProfiles reads the saved profiles. Profiles only prefill the form, so
+// an unreadable profiles file is treated as having none.
+func (m *model) loadProfiles() []profile.Profile {
+	if m.profileStore == nil {
+		return nil
+	}
+	profiles, err := m.profileStore.List()
+	if err != nil {
+		return nil
+	}
+	return profiles
+}
+
+// initProfileForm offers the saved profiles before the language screen.
+// It returns false when there are none to choose from.
+func (m *model) initProfileForm() bool {
+	profiles := m.loadProfiles()
+	if len(profiles) == 0 {
+		return false
+	}
+	opts := []huh.Option[string]{huh.NewOption(bilingual("profile_none"), "")}
+	for _, p := range profiles {
+		opts = append(opts, huh.NewOption(p.Name, p.Name))
+	}
+	m.valProfile = ""
+	m.profileForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(bilingual("profile_prompt")).
+				Description(bilingual("profile_desc")).
+				Options(opts...).
+				Value(&m.valProfile),
+		),
+	).WithTheme(huh.ThemeDracula())
+	m.profileForm.Init()
+	return true
+}
+
+// applyProfile prefills the tax form from a profile.
+func (m *model) applyProfile(p profile.Profile) {
+	m.profile = p
+	m.valEmployee = p.Name
+	m.valParents = strconv.FormatInt(p.DependentParents, 10)
+	m.valSpouse = p.DependentSpouse
+	m.valChildren = strconv.FormatInt(p.Children, 10)
+	m.valSSB = strconv.FormatFloat(p.SSB, 'f', -1, 64)
+	m.dependents = p.Dependents
+}
+
+// chooseProfile moves on from the profile screen, prefilling the form when a
+// profile was selected. The language screen is skipped when the language is
+// already known.
+func (m *model) chooseProfile() {
+	var profileLang langKey
+	if m.valProfile != "" {
+		if p, err := m.profileStore.Get(m.valProfile); err == nil {
+			m.applyProfile(p)
+			profileLang = p.Language
+		}
+	}
+	if m.resolveLang(profileLang) {
+		m.state = stateForm
+		m.initTaxForm()
+		return
+	}
+	m.state = stateLang
+	m.initLangForm()
+}
+
+// initSaveProfileForm asks for the name to save the current values under,
+// defaulting to the loaded profile.
+func (m *model) initSaveProfileForm() {
+	l := m.selectedLang
+	m.valProfile = m.profile.Name
+	m.valEmployeeID = m.profile.EmployeeID
+	m.profileForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(t(l, "profile_name_prompt")).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return errors.New(t(l, "err_profile_name"))
+					}
+					return nil
+				}).
+				Value(&m.valProfile),
+			huh.NewInput().
+				Title(t(l, "profile_employee_prompt")).
+				Value(&m.valEmployeeID),
+		),
+	).WithTheme(huh.ThemeDracula())
+	m.profileForm.Init()
+}
+
+// saveProfile stores the current calculation's dependents and SSB under the
+// entered name and returns the message to show on the result screen.
+func (m *model) saveProfile() string {
+	l := m.selectedLang
+	p := m.profile
+	if p.Name != strings.TrimSpace(m.valProfile) {
+		p = profile.Profile{}
+	}
+	p.Name = strings.TrimSpace(m.valProfile)
+	p.EmployeeID = strings.TrimSpace(m.valEmployeeID)
+	p.DependentParents = m.calcInput.DependentParents
+	p.DependentSpouse = m.calcInput.DependentSpouse == 1
+	p.Children = m.calcInput.Childrens
+	p.SSB = m.calcInput.SSB
+	p.Dependents = m.calcInput.Dependents
+	p.Language = l
+	if m.profileStore == nil {
+		return t(l, "err_profile") + t(l, "err_no_config")
+	}
+	if err := m.profileStore.Save(p); err != nil {
+		return t(l, "err_profile") + err.Error()
+	}
+	m.profile = p
+	if m.valEmployee == "" {
+		m.valEmployee = p.Name
+	}
+	return t(l, "success_profile") + p.Name
+}
+
+// defaultProfileStore opens the profiles file, or returns nil when the
+// configuration directory is unavailable.
+func defaultProfileStore() *profile.Store {
+	s, err := profile.DefaultStore()
+	if err != nil {
+		return nil
+	}
+	return s
+}
@@ -8,6 +8,8 @@ require (
 	github.com/charmbracelet/bubbletea v1.3.10
 	github.com/charmbracelet/huh v1.0.0
 	github.com/charmbracelet/lipgloss v1.1.0
+	github.com/go-text/typesetting v0.3.5
+	golang.org/x/image v0.23.0
 	golang.org/x/text v0.36.0
 )
 
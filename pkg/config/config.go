@@ -0,0 +1,122 @@
+// Package config loads and saves the calculator's user settings, stored as
+// JSON in the user's configuration directory.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// EnvDir overrides the configuration directory when set.
+const EnvDir = "PITCALC_CONFIG_DIR"
+
+const fileName = "config.json"
+
+// DefaultFilenamePattern names exported reports when no pattern is configured.
+const DefaultFilenamePattern = "PIT_Report_{date}"
+
+// Config holds user settings. Zero values mean "use the default".
+type Config struct {
+	// ExportDir is the directory reports are exported to. Empty means the
+	// current working directory.
+	ExportDir string `json:"export_dir,omitempty"`
+	// FilenamePattern names exported reports, without extension. See
+	// report.ExpandFilename for the supported placeholders.
+	FilenamePattern string `json:"filename_pattern,omitempty"`
+	// Language is the preferred display language code ("EN" or "MY").
+	// Empty means detect it from the locale or ask.
+	Language string `json:"language,omitempty"`
+	// MyanmarUnits writes Burmese amounts in lakh and crore instead of with
+	// thousands separators.
+	MyanmarUnits bool `json:"myanmar_units,omitempty"`
+}
+
+// Dir returns the directory holding the calculator's configuration and data
+// files.
+func Dir() (string, error) {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "myanmar-pit-calculator"), nil
+}
+
+// Load reads the configuration file. A missing file is not an error and
+// yields the default configuration.
+func Load() (*Config, error) {
+	dir, err := Dir()
+	if err != nil {
+		return &Config{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, fileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return &Config{}, err
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return &Config{}, err
+	}
+	return &c, nil
+}
+
+// Save writes the configuration file, creating the directory if needed.
+func (c *Config) Save() error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(filepath.Join(dir, fileName), append(data, '\n'), 0644)
+}
+
+// ExportPattern returns the configured filename pattern or the default.
+func (c *Config) ExportPattern() string {
+	if c.FilenamePattern != "" {
+		return c.FilenamePattern
+	}
+	return DefaultFilenamePattern
+}
+
+// WriteFileAtomic writes data to a temporary file next to filename and
+// renames it into place, so readers never observe a partially written file.
+// The parent directory must exist.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad_MissingFileGivesDefaults(t *testing.T) {
+	t.Setenv(EnvDir, t.TempDir())
+
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.ExportDir != "" {
+		t.Errorf("expected empty export dir, got %q", c.ExportDir)
+	}
+	if c.ExportPattern() != DefaultFilenamePattern {
+		t.Errorf("expected %q, got %q", DefaultFilenamePattern, c.ExportPattern())
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested")
+	t.Setenv(EnvDir, dir)
+
+	want := &Config{ExportDir: "/tmp/reports", FilenamePattern: "{name}_{fy}"}
+	if err := want.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got != *want {
+		t.Errorf("expected %+v, got %+v", *want, *got)
+	}
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvDir, dir)
+	os.WriteFile(filepath.Join(dir, fileName), []byte("{"), 0644)
+
+	if _, err := Load(); err == nil {
+		t.Errorf("expected error for invalid config, got nil")
+	}
+}
+
+func TestWriteFileAtomic_MissingDir(t *testing.T) {
+	err := WriteFileAtomic(filepath.Join(t.TempDir(), "missing", "f.txt"), []byte("x"), 0644)
+	if err == nil {
+		t.Errorf("expected error for missing directory, got nil")
+	}
+}
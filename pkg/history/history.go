@@ -0,0 +1,211 @@
+// Package history stores completed calculations in a JSON file in the
+// user's configuration directory so they can be reopened later.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+const fileName = "history.json"
+
+// Entry is a saved calculation.
+type Entry struct {
+	ID        int
+	Label     string
+	Timestamp time.Time
+	// RuleSet is the pitcalc.RuleSetVersion the output was calculated with.
+	RuleSet string
+	Input   pitcalc.CalculatePITInput
+	Output  *pitcalc.CalculatePITOutput
+}
+
+// ErrNotFound is returned for an unknown entry ID.
+var ErrNotFound = errors.New("history entry not found")
+
+// Store reads and writes the history file.
+type Store struct {
+	path string
+}
+
+// NewStore returns a store backed by the given file.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStore returns the store in the configuration directory.
+func DefaultStore() (*Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(filepath.Join(dir, fileName)), nil
+}
+
+// storedBracket mirrors a tax breakdown entry with the open top bracket's
+// infinite limit encoded as null, which JSON cannot otherwise represent.
+type storedBracket struct {
+	Start  float64
+	Limit  *float64
+	Rate   float64
+	Amount float64
+}
+
+type storedOutput struct {
+	pitcalc.CalculatePITOutput
+	TaxBreakdown []storedBracket
+}
+
+type storedEntry struct {
+	ID        int
+	Label     string `json:",omitempty"`
+	Timestamp time.Time
+	RuleSet   string
+	Input     pitcalc.CalculatePITInput
+	Output    storedOutput
+}
+
+func toStored(e Entry) storedEntry {
+	out := storedOutput{CalculatePITOutput: *e.Output}
+	out.CalculatePITOutput.TaxBreakdown = nil
+	for _, v := range e.Output.TaxBreakdown {
+		b := storedBracket{Start: v.Start, Rate: v.Rate, Amount: v.Amount}
+		if !math.IsInf(v.Limit, 1) {
+			limit := v.Limit
+			b.Limit = &limit
+		}
+		out.TaxBreakdown = append(out.TaxBreakdown, b)
+	}
+	return storedEntry{e.ID, e.Label, e.Timestamp, e.RuleSet, e.Input, out}
+}
+
+func fromStored(s storedEntry) Entry {
+	out := s.Output.CalculatePITOutput
+	out.TaxBreakdown = nil
+	for _, v := range s.Output.TaxBreakdown {
+		limit := math.Inf(1)
+		if v.Limit != nil {
+			limit = *v.Limit
+		}
+		out.TaxBreakdown = append(out.TaxBreakdown, struct {
+			Start  float64
+			Limit  float64
+			Rate   float64
+			Amount float64
+		}{v.Start, limit, v.Rate, v.Amount})
+	}
+	return Entry{s.ID, s.Label, s.Timestamp, s.RuleSet, s.Input, &out}
+}
+
+// List returns all entries, newest first. A missing history file yields no
+// entries.
+func (s *Store) List() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored []storedEntry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	entries := make([]Entry, len(stored))
+	for i, e := range stored {
+		entries[i] = fromStored(e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+func (s *Store) save(entries []Entry) error {
+	stored := make([]storedEntry, len(entries))
+	for i, e := range entries {
+		stored[i] = toStored(e)
+	}
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(s.path, append(data, '\n'), 0644)
+}
+
+// Add saves a calculation and returns the stored entry with its ID,
+// timestamp and rule-set version filled in.
+func (s *Store) Add(label string, in pitcalc.CalculatePITInput, out *pitcalc.CalculatePITOutput) (Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return Entry{}, err
+	}
+	e := Entry{
+		ID:        1,
+		Label:     label,
+		Timestamp: time.Now().Truncate(time.Second),
+		RuleSet:   pitcalc.RuleSetVersion,
+		Input:     in,
+		Output:    out,
+	}
+	if len(entries) > 0 {
+		e.ID = entries[0].ID + 1
+	}
+	return e, s.save(append(entries, e))
+}
+
+// Get returns the entry with the given ID.
+func (s *Store) Get(id int) (Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return Entry{}, err
+	}
+	for _, e := range entries {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return Entry{}, ErrNotFound
+}
+
+// SetLabel changes the label of the entry with the given ID.
+func (s *Store) SetLabel(id int, label string) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			entries[i].Label = label
+			return s.save(entries)
+		}
+	}
+	return ErrNotFound
+}
+
+// Delete removes the entry with the given ID.
+func (s *Store) Delete(id int) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	for i, e := range entries {
+		if e.ID == id {
+			return s.save(append(entries[:i], entries[i+1:]...))
+		}
+	}
+	return ErrNotFound
+}
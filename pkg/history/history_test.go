@@ -0,0 +1,102 @@
+package history
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+func calculate(t *testing.T, income float64) (pitcalc.CalculatePITInput, *pitcalc.CalculatePITOutput) {
+	t.Helper()
+	in := pitcalc.CalculatePITInput{MonthlyIncome: income, StartingMonth: 4}
+	out, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return in, out
+}
+
+func TestStore_AddListGet(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "nested", fileName))
+
+	entries, err := s.List()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected empty history, got %d entries and %v", len(entries), err)
+	}
+
+	in, out := calculate(t, 10000000)
+	first, err := s.Add("January payroll", in, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := s.Add("", in, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("expected IDs 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+	if first.RuleSet != pitcalc.RuleSetVersion {
+		t.Errorf("expected rule set %q, got %q", pitcalc.RuleSetVersion, first.RuleSet)
+	}
+
+	entries, err = s.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != 2 {
+		t.Fatalf("expected 2 entries, newest first, got %+v", entries)
+	}
+
+	got, err := s.Get(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Label != "January payroll" || got.Output.TotalTax != out.TotalTax {
+		t.Errorf("expected stored calculation, got %+v", got)
+	}
+	top := got.Output.TaxBreakdown[len(got.Output.TaxBreakdown)-1]
+	if !math.IsInf(top.Limit, 1) {
+		t.Errorf("expected the top bracket limit to round-trip as +Inf, got %f", top.Limit)
+	}
+}
+
+func TestStore_SetLabelAndDelete(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), fileName))
+	in, out := calculate(t, 1000000)
+	e, _ := s.Add("", in, out)
+
+	if err := s.SetLabel(e.ID, "Claim parents"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := s.Get(e.ID); got.Label != "Claim parents" {
+		t.Errorf("expected label %q, got %q", "Claim parents", got.Label)
+	}
+
+	if err := s.Delete(e.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Get(e.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := s.Delete(e.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDefaultStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvDir, dir)
+
+	s, err := DefaultStore()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.path != filepath.Join(dir, fileName) {
+		t.Errorf("expected history in %s, got %s", dir, s.path)
+	}
+}
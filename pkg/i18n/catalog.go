@@ -0,0 +1,282 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+)
+
+// langDir is the directory, inside the configuration directory, searched for
+// language catalogue files.
+const langDir = "lang"
+
+var (
+	// builder holds the messages of every language for formatting by Tf.
+	builder = catalog.NewBuilder(catalog.Fallback(language.English))
+	// printers formats each language's messages with its plural rules and
+	// digits.
+	printers = map[Lang]*message.Printer{}
+	// names are the languages' names in their own script, for the language
+	// selector.
+	names = map[Lang]string{EN: "English", MY: "မြန်မာ"}
+	// builtin lists the languages compiled in, in the order they are offered.
+	builtin = []Lang{EN, MY}
+)
+
+func init() {
+	tags := map[Lang]language.Tag{EN: language.English, MY: language.Burmese}
+	for _, l := range builtin {
+		if err := register(l, tags[l], messages[l], plurals[l]); err != nil {
+			panic(fmt.Sprintf("i18n: %s: %v", l, err))
+		}
+	}
+}
+
+// pluralForms are the CLDR plural categories a plural string may give, in
+// the order they are tried; "other" is required and matches any count.
+var pluralForms = []string{"zero", "one", "two", "few", "many", "other"}
+
+// register adds strings in a language to the catalogue, replacing any with
+// the same id.
+func register(l Lang, tag language.Tag, msgs map[string]string, plurs map[string]map[string]string) error {
+	for id, s := range msgs {
+		if err := builder.SetString(tag, id, s); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+	}
+	for id, forms := range plurs {
+		if _, ok := forms["other"]; !ok {
+			return fmt.Errorf("%s: missing plural form \"other\"", id)
+		}
+		var cases []any
+		for _, f := range pluralForms {
+			if s, ok := forms[f]; ok {
+				cases = append(cases, f, s)
+			}
+		}
+		if len(cases) != 2*len(forms) {
+			return fmt.Errorf("%s: unknown plural form (want %s)", id, strings.Join(pluralForms, ", "))
+		}
+		if err := builder.Set(tag, id, plural.Selectf(1, "%d", cases...)); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+	}
+
+	if messages[l] == nil {
+		messages[l] = map[string]string{}
+	}
+	for id, s := range msgs {
+		messages[l][id] = s
+	}
+	if plurals[l] == nil {
+		plurals[l] = map[string]map[string]string{}
+	}
+	for id, forms := range plurs {
+		plurals[l][id] = forms
+	}
+	printers[l] = message.NewPrinter(tag, message.Catalog(builder))
+	return nil
+}
+
+// lookup returns the language whose strings hold id: lang itself, English
+// when it is not translated, or "" when there is no such string.
+func lookup(lang Lang, id string) Lang {
+	for _, l := range []Lang{lang, EN} {
+		if _, ok := messages[l][id]; ok {
+			return l
+		}
+		if _, ok := plurals[l][id]; ok {
+			return l
+		}
+	}
+	return ""
+}
+
+// Languages returns the available languages: the built-in ones first, then
+// those loaded from files by code.
+func Languages() []Lang {
+	langs := append([]Lang(nil), builtin...)
+	var loaded []Lang
+	for l := range printers {
+		if !isBuiltin(l) {
+			loaded = append(loaded, l)
+		}
+	}
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i] < loaded[j] })
+	return append(langs, loaded...)
+}
+
+// Available reports whether a language is built in or has been loaded.
+func Available(lang Lang) bool {
+	_, ok := printers[lang]
+	return ok
+}
+
+func isBuiltin(l Lang) bool {
+	for _, b := range builtin {
+		if l == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns a language's name in its own script, e.g. "မြန်မာ".
+func Name(lang Lang) string {
+	if n, ok := names[lang]; ok {
+		return n
+	}
+	return string(lang)
+}
+
+// ids returns the ids of a language's strings, plural or not.
+func ids(lang Lang) map[string]bool {
+	set := map[string]bool{}
+	for id := range messages[lang] {
+		set[id] = true
+	}
+	for id := range plurals[lang] {
+		set[id] = true
+	}
+	return set
+}
+
+// IDs returns the ids of the English strings, which every language may
+// translate, sorted.
+func IDs() []string {
+	return difference(ids(EN), nil)
+}
+
+// Untranslated returns the ids of the English strings a language does not
+// translate, sorted. They are shown in English.
+func Untranslated(lang Lang) []string {
+	return difference(ids(EN), ids(lang))
+}
+
+// Unknown returns the ids a language translates that English does not have,
+// such as misspelt or retired ids, sorted. They are never shown.
+func Unknown(lang Lang) []string {
+	return difference(ids(lang), ids(EN))
+}
+
+// difference returns the ids in a that are not in b, sorted.
+func difference(a, b map[string]bool) []string {
+	var out []string
+	for id := range a {
+		if !b[id] {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// langFile is the layout of a language catalogue file. Each message is
+// either a string or an object of plural forms such as
+// {"one": "%d scenario", "other": "%d scenarios"}.
+type langFile struct {
+	// Lang is the language code used by --lang and the configuration.
+	Lang string `json:"lang"`
+	// Name is the language's name in its own script.
+	Name string `json:"name"`
+	// Tag is the BCP 47 tag choosing plural rules and number formatting;
+	// it defaults to the code.
+	Tag      string                     `json:"tag"`
+	Messages map[string]json.RawMessage `json:"messages"`
+}
+
+// LoadFile adds the language in a catalogue file, or extends a built-in one.
+// Strings the file does not translate fall back to English.
+func LoadFile(path string) (Lang, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var f langFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", err
+	}
+
+	code := strings.TrimSpace(f.Lang)
+	if code == "" || strings.IndexFunc(code, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+	}) >= 0 {
+		return "", fmt.Errorf("invalid language code %q", f.Lang)
+	}
+	l := Lang(strings.ToUpper(code))
+	tagText := f.Tag
+	if tagText == "" {
+		tagText = strings.ToLower(code)
+	}
+	tag, err := language.Parse(tagText)
+	if err != nil {
+		return "", fmt.Errorf("invalid language tag %q: %w", tagText, err)
+	}
+
+	msgs := map[string]string{}
+	plurs := map[string]map[string]string{}
+	for id, raw := range f.Messages {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			msgs[id] = s
+			continue
+		}
+		var forms map[string]string
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return "", fmt.Errorf("%s: want a string or plural forms", id)
+		}
+		plurs[id] = forms
+	}
+	if err := register(l, tag, msgs, plurs); err != nil {
+		return "", err
+	}
+	if f.Name != "" {
+		names[l] = f.Name
+	}
+	return l, nil
+}
+
+// LoadDir loads every .json catalogue file in dir. A missing directory
+// holds no languages; files that fail to load are reported together while
+// the others are still loaded.
+func LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		if _, err := LoadFile(filepath.Join(dir, e.Name())); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadUserDir loads the catalogue files in the "lang" directory of the
+// configuration directory.
+func LoadUserDir() error {
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	return LoadDir(filepath.Join(dir, langDir))
+}
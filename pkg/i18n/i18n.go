@@ -0,0 +1,636 @@
+// Package i18n holds the user-facing strings shared by the command line and
+// terminal UI front-ends, in English and Burmese, and any further languages
+// loaded from catalogue files.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lang identifies a display language by its upper-case code, e.g. "EN".
+type Lang string
+
+const (
+	EN Lang = "EN"
+	MY Lang = "MY"
+)
+
+// messages holds the built-in strings. Strings used with Tf hold fmt verbs;
+// those returned by T are shown as written.
+var messages = map[Lang]map[string]string{
+	EN: {
+		"title":                    "🇲🇲 Myanmar PIT Calculator",
+		"lang_prompt":              "Select Language",
+		"income_group":             "Income Details",
+		"salary_prompt":            "Monthly Salary (MMK)",
+		"bonus_prompt":             "Yearly Bonus (MMK) [Optional]",
+		"reliefs_group":            "Tax Reliefs",
+		"spouse_prompt":            "Dependent Spouse?",
+		"spouse_desc":              "Is your spouse currently unemployed or not earning?",
+		"children_prompt":          "Number of Dependent Children",
+		"parents_prompt":           "Number of Dependent Parents",
+		"other_group":              "Other Allowances",
+		"ssb_prompt":               "Total SSB Contribution (MMK)",
+		"calculating":              "Calculating...",
+		"err_validation":           "❌ Invalid input, please fix errors.",
+		"err_numeric":              "Must be a valid number",
+		"err_negative":             "Cannot be negative",
+		"err_parents":              "Parents must be 0, 1, or 2",
+		"err_ssb":                  "Maximum SSB is 360,000",
+		"res_income":               "📊 Income Details",
+		"res_reliefs":              "🛡️  Tax Reliefs",
+		"res_total_income":         "Total Taxable Income",
+		"res_total_reliefs":        "Total Reliefs",
+		"res_final_tax":            "💎 Final Tax",
+		"live_preview":             "🧮 Live Estimate",
+		"preview_no_income":        "Enter a monthly salary to see the estimate",
+		"export_prompt":            "Choose Export Format",
+		"template_prompt":          "Report Template",
+		"template_desc":            "Built-in template name or path to a template file. Built-in:",
+		"err_template":             "Template not found or invalid",
+		"success_copy":             "📋 Copied to clipboard!",
+		"success_export":           "📁 Exported to ",
+		"export_dir_prompt":        "Output Directory",
+		"export_dir_desc":          "Leave empty for the current directory.",
+		"export_pattern_prompt":    "File Name",
+		"export_pattern_desc":      "Without extension. Placeholders: {date} {time} {name} {fy}",
+		"export_name_prompt":       "Employee Name [Optional]",
+		"overwrite_prompt":         "File already exists. Overwrite?",
+		"export_cancelled":         "Export cancelled, existing file kept.",
+		"err_export":               "Export failed: ",
+		"help_footer":              "c: Copy • e: Export • r: Edit • n: New • s: Save scenario • v: Compare • a: Advisor • ?: Explain • h: History • p: Save profile • l: Language • u: Lakh/Crore • ctrl+u/ctrl+d: Scroll • q: Quit",
+		"profile_prompt":           "Load a Profile",
+		"profile_desc":             "Prefills dependents, spouse and SSB",
+		"profile_none":             "(none)",
+		"profile_name_prompt":      "Profile Name",
+		"profile_employee_prompt":  "Employee ID (optional)",
+		"err_profile_name":         "Name is required",
+		"success_profile":          "👤 Saved profile: ",
+		"err_profile":              "Profile not saved: ",
+		"history_title":            "🗂️  Saved Calculations",
+		"history_footer":           "↑/↓: Select • enter: Open • d: Duplicate • x: Delete • b: Back • q: Quit",
+		"history_empty":            "No saved calculations yet.",
+		"history_rules":            "rules",
+		"err_history":              "History not saved: ",
+		"scenario_prompt":          "Scenario Name",
+		"scenario_default":         "Scenario",
+		"err_scenario":             "Name is required",
+		"success_scenario":         "💾 Saved scenario: ",
+		"compare_title":            "⚖️  Scenario Comparison",
+		"compare_footer":           "e: Export comparison • d: Delete last scenario • b: Back • q: Quit",
+		"compare_empty":            "No scenarios saved yet. Press s on a result to save one.",
+		"res_diff":                 "Δ",
+		"advice_title":             "💡 Tax Advisor",
+		"advice_footer":            "tab: Pair with a saved scenario • b: Back • q: Quit",
+		"advice_you":               "You",
+		"advice_paired":            "Paired with scenario: %s",
+		"advice_unpaired":          "Save a scenario (s) of a spouse or relative to see how to share the dependent relief.",
+		"advice_current":           "Tax as entered",
+		"advice_total_tax":         "Total Tax",
+		"advice_saving":            "Saving",
+		"advice_entered":           "as entered",
+		"advice_best":              "Claiming the dependents as in the first row saves %s a year.",
+		"advice_best_entered":      "The dependents are already claimed in the best way.",
+		"advice_ssb":               "%s: contributing %s more to SSB, up to the limit, saves %s.",
+		"advice_ssb_no_saving":     "%s: contributing %s more to SSB would not lower the tax.",
+		"advice_ssb_full":          "%s: SSB is already at the limit.",
+		"advice_bracket":           "%s: deducting a further %s, such as life insurance premiums, brings the taxable income into the %s bracket and saves %s.",
+		"advice_lowest":            "%s: the taxable income is already in the lowest bracket.",
+		"household_title":          "🏠 Household Tax",
+		"household_total":          "Household",
+		"household_claims":         "Dependents Claimed",
+		"household_best":           "The dependents are claimed in the way that pays the least tax.",
+		"household_spouse_dropped": "%s claimed the spouse relief, which does not apply when both spouses earn; it is left out.",
+		"cli_err_household":        "The saved calculations claim dependents by number, so the household's cannot be told from them; give --parents and --children.",
+		"res_history":              "📜 Previous Calculations",
+		"res_gross_income":         "Gross Income (Yearly)",
+		"res_basic_relief":         "Basic (20%, max 10M)",
+		"res_parent_relief":        "Parents",
+		"res_spouse_relief":        "Spouse",
+		"res_child_relief":         "Children",
+		"res_ssb_relief":           "SSB",
+		"res_brackets":             "📈 Tax Breakdown",
+		"res_from":                 "From",
+		"res_to":                   "To",
+		"res_tax_amount":           "Tax Amount",
+		"res_and_above":            "And above",
+		"res_item":                 "Item",
+		"res_amount":               "Amount",
+		"start_month_prompt":       "Starting Month",
+		"fiscal_year_prompt":       "Fiscal Year",
+		"start_month_desc":         "First month of employment in the fiscal year",
+		"end_month_prompt":         "Ending Month",
+		"end_month_desc":           "Last month of employment; keep the year's last month if still employed at year end",
+		"err_end_month":            "Ending month cannot be before the starting month",
+		"err_month_year":           "This month is not in the fiscal year",
+		"chart_brackets":           "📊 Tax by Bracket",
+		"chart_sensitivity":        "📈 Tax Across Incomes (50%–150% of your salary)",
+		"chart_total_tax":          "Total tax",
+		"chart_effective":          "Effective rate",
+		"chart_you":                "your salary",
+		"res_months":               "Months Counted",
+		"fiscal_year":              "FY %s",
+		"fiscal_year_interim":      "Interim FY %s",
+		"proration_calendar":       "%s (%d of %d days)",
+		"proration_working":        "%s (%d of %d working days)",
+		"res_dependents":           "👪 Dependents",
+		"res_dependents_decision":  "Decision",
+		"res_dependents_relief":    "Dependent Reliefs",
+		"dep_parent":               "Parent",
+		"dep_spouse":               "Spouse",
+		"dep_child":                "Child",
+		"dep_other":                "Unknown",
+		"dep_accepted":             "Accepted: %s",
+		"dep_rejected":             "Rejected: %s",
+		"dep_child_minor":          "under 18",
+		"dep_child_student":        "a full-time student",
+		"dep_child_disabled":       "disabled",
+		"dep_child_adult":          "18 or over, and neither a student nor disabled",
+		"dep_child_no_birth_date":  "no birth date to check the age limit",
+		"dep_child_no_fiscal_year": "no fiscal year to check the age limit",
+		"dep_parent_lives_with":    "lives with the taxpayer",
+		"dep_parent_apart":         "does not live with the taxpayer",
+		"dep_parent_limit":         "no more than 2 parents can be claimed",
+		"dep_spouse_no_income":     "has no income",
+		"dep_spouse_earns":         "has an income",
+		"dep_spouse_limit":         "only one spouse can be claimed",
+		"dep_unknown_relationship": "the relationship is not a parent, spouse or child",
+		"cli_err_dependents":       "Could not read the dependents: %v",
+		"cli_dependents":           "Dependents",
+		"dependents_listed_desc":   "Listed in full; each is checked for eligibility when calculating.",
+		"explain_title":            "🧮 How was this calculated?",
+		"explain_footer":           "b: Back • q: Quit",
+		"explain_step":             "Step",
+		"explain_working":          "Working",
+		"explain_months":           "%s to %s",
+		"explain_proration":        "%s, employed part of the month",
+		"explain_proration_rule":   "%s × %d/%d days",
+		"explain_gross":            "%s a month × %d",
+		"explain_gross_part":       "%s a month × %d, plus the part months above",
+		"explain_basic":            "Basic relief",
+		"explain_basic_rule":       "%s of %s, at most %s",
+		"explain_basic_capped":     "%s of %s, capped at %s",
+		"explain_parent":           "Parent relief",
+		"explain_parent_rule":      "%d × %s, for at most %d parents living with the taxpayer",
+		"explain_spouse":           "Spouse relief",
+		"explain_spouse_rule":      "%d × %s, for a spouse without income",
+		"explain_child":            "Child relief",
+		"explain_child_rule":       "%d × %s, for each child under 18, studying or disabled",
+		"explain_ssb":              "SSB contributions",
+		"explain_ssb_rule":         "deducted as contributed",
+		"explain_total_relief":     "the sum of the reliefs above",
+		"explain_taxable":          "%s − %s",
+		"explain_taxable_zero":     "%s − %s, taken as zero",
+		"explain_bracket":          "Tax from %s to %s",
+		"explain_bracket_top":      "Tax above %s",
+		"explain_bracket_rule":     "%s of %s",
+		"explain_total_tax":        "the sum of the bracket taxes",
+		"err_no_config":            "no configuration directory",
+		"err_copy":                 "Failed to copy",
+		"err_prefix":               "Error: ",
+		"error_footer":             "r: Edit • n: New • h: History • l: Language • q: Quit",
+		"format_txt":               "TXT Document",
+		"format_json":              "JSON Data",
+		"format_csv":               "CSV Spreadsheet",
+		"format_md":                "Markdown",
+		"format_html":              "HTML Page",
+		"format_pdf":               "PDF Document",
+		"format_xlsx":              "Excel Workbook",
+		"format_template":          "Custom Template",
+		"cli_title":                "🇲🇲 Myanmar PIT Calculator (CLI)",
+		"cli_income_prompt":        "Enter monthly income (MMK): ",
+		"cli_start_prompt":         "Enter starting month (1 = Jan, 2 = Feb, ..., 12 = Dec): ",
+		"cli_end_prompt":           "Enter ending month (1-12, 3 = Mar if employed until the end of the year): ",
+		"cli_parents_prompt":       "Enter number of dependent parents (1,000,000 MMK for each): ",
+		"cli_spouse_prompt":        "Do you have a dependent spouse? (1 = Yes, 0 = No): ",
+		"cli_children_prompt":      "Enter number of children (500,000 MMK for each): ",
+		"cli_ssb_prompt":           "Enter total SSB contribution yearly (MMK): ",
+		"cli_err_invalid":          "Invalid input, try again.",
+		"cli_err_income":           "Monthly income must be greater than 0.",
+		"cli_err_start":            "Starting month must be between 1 and 12.",
+		"cli_err_end":              "Ending month must be between 1 and 12.",
+		"cli_err_end_before":       "Ending month cannot be before the starting month in the April–March year.",
+		"cli_err_end_before_fy":    "Ending month cannot be before the starting month in %s.",
+		"cli_err_month_year":       "This month is not in %s.",
+		"cli_end_prompt_fy":        "Enter ending month (1-12, %d = %s if employed until the end of the year): ",
+		"cli_err_fy":               "Unknown fiscal year %q; use e.g. 2026-27.",
+		"cli_err_date":             "Invalid date %q; use YYYY-MM-DD.",
+		"cli_err_prorate":          "Unknown proration %q; use calendar or working.",
+		"cli_err_prorate_dates":    "--prorate needs --joined or --left.",
+		"cli_err_employment":       "The dates joined and left are not within %s.",
+		"cli_err_parents_negative": "Number of dependent parents cannot be negative.",
+		"cli_err_parents_max":      "Number of dependent parents cannot exceed %d.",
+		"cli_err_spouse":           "Invalid input. Please enter 1 for Yes or 0 for No.",
+		"cli_err_children":         "Number of children cannot be negative.",
+		"cli_err_ssb":              "Yearly SSB contribution cannot be negative.",
+		"cli_err_lang":             "Unsupported language %q.",
+		"cli_err_calc":             "Error in calculating PIT: %v",
+		"calc_income":              "Monthly income must be greater than 0",
+		"calc_proration":           "Unknown proration; use calendar or working",
+		"calc_start_month":         "Starting month must be between 1 and 12",
+		"calc_end_month":           "Ending month must be between 1 and 12",
+		"calc_parents_negative":    "Number of dependent parents cannot be negative",
+		"calc_parents_max":         "Number of dependent parents cannot exceed %d",
+		"calc_spouse":              "Dependent spouse must be 0 or 1",
+		"calc_children":            "Number of children cannot be negative",
+		"calc_ssb":                 "Yearly SSB contribution cannot be negative",
+		"calc_no_fy":               "A fiscal year is needed for the dates joined and left",
+		"calc_not_employed":        "The dates joined and left are not within the fiscal year",
+		"calc_household_negative":  "The number of household dependents cannot be negative",
+		"calc_household_parents":   "The spouses cannot claim more than %d parents between them",
+		"calc_household_claims":    "The spouses claim more parents or children than the household has",
+		"calc_earning_spouse":      "A spouse who earns cannot be claimed as a dependent spouse",
+		"calc_listed_twice":        "Spouse %d lists %s twice",
+		"calc_claimed_twice":       "%s is claimed by both spouses",
+		"cli_err_template":         "Template failed: %v",
+		"cli_using_profile":        "👤 Using profile %s",
+		"cli_total_tax":            "Total Personal Income Tax",
+		"cli_above":                "Above from %s",
+		"cli_up_to":                "Up to %s",
+		"cli_history_saved":        "🗂️  Saved to history as #%d",
+		"cli_history_header":       "ID\tDate\tGross Income\tTotal Tax\tRules\tLabel",
+		"cli_history_unknown":      "No saved calculation has the ID or label %q.",
+		"cli_history_ambiguous":    "More than one saved calculation is labelled %q (%s); give its ID.",
+		"cli_history_not_found":    "History entry #%d not found.",
+		"cli_rules":                "Rules",
+		"cli_profile_empty":        "No saved profiles.",
+		"cli_profile_header":       "Name\tEmployee ID\tParents\tSpouse\tChildren\tSSB",
+		"cli_profile_not_found":    "Profile %q not found.",
+		"cli_profile_saved":        "👤 Saved profile %s",
+		"cli_profile_deleted":      "🗑️  Deleted profile %s",
+		"cli_name":                 "Name",
+		"cli_employee_id":          "Employee ID",
+		"cli_language":             "Language",
+		"cli_yes":                  "Yes",
+		"cli_no":                   "No",
+		"cli_lang_header":          "Code\tName\tTranslated",
+		"cli_lang_complete":        "all strings translated",
+		"month_1":                  "January",
+		"month_2":                  "February",
+		"month_3":                  "March",
+		"month_4":                  "April",
+		"month_5":                  "May",
+		"month_6":                  "June",
+		"month_7":                  "July",
+		"month_8":                  "August",
+		"month_9":                  "September",
+		"month_10":                 "October",
+		"month_11":                 "November",
+		"month_12":                 "December",
+	},
+	MY: {
+		"title":                    "🇲🇲 မြန်မာ ဝင်ငွေခွန် တွက်စက်",
+		"lang_prompt":              "ဘာသာစကား ရွေးချယ်ပါ",
+		"income_group":             "ဝင်ငွေ အသေးစိတ်",
+		"salary_prompt":            "လစဉ်လစာ (ကျပ်)",
+		"bonus_prompt":             "နှစ်စဉ် ဆုကြေး (ကျပ်) [ရွေးချယ်ရန်]",
+		"reliefs_group":            "အခွန်သက်သာခွင့်များ",
+		"spouse_prompt":            "မှီခို ဇနီး/ခင်ပွန်း ရှိပါသလား?",
+		"spouse_desc":              "အလုပ်လုပ်ကိုင်ခြင်းမရှိသော အိမ်ထောင်ဖက်",
+		"children_prompt":          "မှီခို ကလေး အရေအတွက်",
+		"parents_prompt":           "မှီခို မိဘ အရေအတွက်",
+		"other_group":              "အခြားသော ခွင့်ပြုချက်များ",
+		"ssb_prompt":               "လူမှုဖူလုံရေး ထည့်ဝင်ငွေ စုစုပေါင်း (ကျပ်)",
+		"calculating":              "တွက်ချက်နေပါသည်...",
+		"err_validation":           "❌ ထည့်သွင်းထားသော အချက်အလက်များ မှားယွင်းနေပါသည်။",
+		"err_numeric":              "ကိန်းဂဏန်းသာ ဖြစ်ရမည်",
+		"err_negative":             "အနုတ်မရပါ",
+		"err_parents":              "မိဘ ယောက်ရေ ၀, ၁, သို့မဟုတ် ၂ သာ ထည့်ပါ",
+		"err_ssb":                  "အများဆုံး ထည့်ဝင်ငွေ ၃၆၀,၀၀၀ ဖြစ်သည်",
+		"res_income":               "📊 ဝင်ငွေ အသေးစိတ်",
+		"res_reliefs":              "🛡️  အခွန်သက်သာခွင့်များ",
+		"res_total_income":         "အခွန်စည်းကြပ်ရန် ဝင်ငွေ",
+		"res_total_reliefs":        "သက်သာခွင့် စုစုပေါင်း",
+		"res_final_tax":            "💎 ကျသင့် အခွန်ငွေ",
+		"live_preview":             "🧮 လက်ရှိ ခန့်မှန်းချက်",
+		"preview_no_income":        "ခန့်မှန်းချက် ကြည့်ရန် လစဉ် လစာ ထည့်ပါ",
+		"export_prompt":            "ပို့ဆောင်မည့် ပုံစံရွေးပါ",
+		"template_prompt":          "အစီရင်ခံစာ ပုံစံ",
+		"template_desc":            "ပါဝင်ပြီးသား ပုံစံအမည် သို့မဟုတ် ပုံစံဖိုင် လမ်းကြောင်း။ ပါဝင်ပြီးသား:",
+		"err_template":             "ပုံစံဖိုင် မတွေ့ပါ သို့မဟုတ် မှားယွင်းနေပါသည်",
+		"success_copy":             "📋 ကူးယူပြီးပါပြီ!",
+		"success_export":           "📁 မှတ်တမ်းတင်ပြီးပါပြီ: ",
+		"export_dir_prompt":        "သိမ်းဆည်းမည့် ဖိုင်တွဲ",
+		"export_dir_desc":          "လက်ရှိ ဖိုင်တွဲအတွက် အလွတ်ထားပါ။",
+		"export_pattern_prompt":    "ဖိုင်အမည်",
+		"export_pattern_desc":      "တိုးချဲ့အမည် မပါဘဲ။ အစားထိုးရန်: {date} {time} {name} {fy}",
+		"export_name_prompt":       "ဝန်ထမ်း အမည် [ရွေးချယ်ရန်]",
+		"overwrite_prompt":         "ဖိုင် ရှိပြီးသားဖြစ်သည်။ အစားထိုးမလား?",
+		"export_cancelled":         "ဖိုင်ထုတ်ခြင်း ပယ်ဖျက်ပြီး မူလဖိုင်ကို ထားရှိပါသည်။",
+		"err_export":               "ဖိုင်ထုတ်ခြင်း မအောင်မြင်ပါ: ",
+		"help_footer":              "c: ကူးယူမည် • e: ဖိုင်ထုတ်မည် • r: ပြင်ဆင်မည် • n: အသစ်တွက်မည် • s: အခြေအနေ သိမ်းမည် • v: နှိုင်းယှဉ်မည် • a: အကြံပေး • ?: ရှင်းလင်းချက် • h: မှတ်တမ်း • p: ပရိုဖိုင် သိမ်းမည် • l: ဘာသာစကား • u: သိန်း/ကုဋေ • ctrl+u/ctrl+d: လှိမ့်ကြည့်မည် • q: ထွက်မည်",
+		"profile_prompt":           "ပရိုဖိုင် ဖွင့်မည်",
+		"profile_desc":             "မှီခိုသူ၊ အိမ်ထောင်ဖက်နှင့် SSB တို့ကို ကြိုတင်ဖြည့်ပေးမည်",
+		"profile_none":             "(မရွေးပါ)",
+		"profile_name_prompt":      "ပရိုဖိုင် အမည်",
+		"profile_employee_prompt":  "ဝန်ထမ်း အမှတ် (မဖြည့်လည်း ရသည်)",
+		"err_profile_name":         "အမည် ထည့်ရန် လိုအပ်ပါသည်",
+		"success_profile":          "👤 ပရိုဖိုင် သိမ်းပြီးပါပြီ: ",
+		"err_profile":              "ပရိုဖိုင် မသိမ်းနိုင်ပါ: ",
+		"history_title":            "🗂️  သိမ်းထားသော တွက်ချက်မှုများ",
+		"history_footer":           "↑/↓: ရွေးမည် • enter: ဖွင့်မည် • d: ပွားမည် • x: ဖျက်မည် • b: နောက်သို့ • q: ထွက်မည်",
+		"history_empty":            "သိမ်းထားသော တွက်ချက်မှု မရှိသေးပါ။",
+		"history_rules":            "စည်းမျဉ်း",
+		"err_history":              "မှတ်တမ်း မသိမ်းနိုင်ပါ: ",
+		"scenario_prompt":          "အခြေအနေ အမည်",
+		"scenario_default":         "အခြေအနေ",
+		"err_scenario":             "အမည် ထည့်ရန် လိုအပ်ပါသည်",
+		"success_scenario":         "💾 အခြေအနေ သိမ်းပြီးပါပြီ: ",
+		"compare_title":            "⚖️  အခြေအနေများ နှိုင်းယှဉ်ချက်",
+		"compare_footer":           "e: နှိုင်းယှဉ်ချက် ဖိုင်ထုတ်မည် • d: နောက်ဆုံး အခြေအနေ ဖျက်မည် • b: နောက်သို့ • q: ထွက်မည်",
+		"compare_empty":            "သိမ်းထားသော အခြေအနေ မရှိသေးပါ။ ရလဒ်စာမျက်နှာတွင် s နှိပ်၍ သိမ်းပါ။",
+		"res_diff":                 "Δ",
+		"advice_title":             "💡 အခွန် အကြံပေး",
+		"advice_footer":            "tab: သိမ်းထားသော အခြေအနေနှင့် တွဲမည် • b: နောက်သို့ • q: ထွက်မည်",
+		"advice_you":               "သင်",
+		"advice_paired":            "တွဲထားသော အခြေအနေ: %s",
+		"advice_unpaired":          "မှီခိုသူ သက်သာခွင့်ကို မည်သို့ ခွဲဝေရမည်ကို ကြည့်ရန် ခင်ပွန်း/ဇနီး သို့မဟုတ် ဆွေမျိုး၏ အခြေအနေကို (s) ဖြင့် သိမ်းပါ။",
+		"advice_current":           "ထည့်သွင်းထားသည့်အတိုင်း အခွန်",
+		"advice_total_tax":         "စုစုပေါင်း အခွန်",
+		"advice_saving":            "သက်သာငွေ",
+		"advice_entered":           "ထည့်သွင်းထားသည့်အတိုင်း",
+		"advice_best":              "ပထမ အတန်းအတိုင်း မှီခိုသူများကို တောင်းဆိုပါက တစ်နှစ်လျှင် %s သက်သာပါသည်။",
+		"advice_best_entered":      "မှီခိုသူများကို အကောင်းဆုံး ပုံစံဖြင့် တောင်းဆိုထားပြီး ဖြစ်ပါသည်။",
+		"advice_ssb":               "%s: လူမှုဖူလုံရေး ထည့်ဝင်ငွေကို ကန့်သတ်ချက်အထိ %s ထပ်ထည့်ပါက %s သက်သာပါသည်။",
+		"advice_ssb_no_saving":     "%s: လူမှုဖူလုံရေး ထည့်ဝင်ငွေ %s ထပ်ထည့်သော်လည်း အခွန် မလျော့ပါ။",
+		"advice_ssb_full":          "%s: လူမှုဖူလုံရေး ထည့်ဝင်ငွေ ကန့်သတ်ချက်အထိ ရောက်ပြီး ဖြစ်ပါသည်။",
+		"advice_bracket":           "%s: အသက်အာမခံ ပရီမီယံကဲ့သို့ %s ထပ်မံ နုတ်ယူပါက အခွန်စည်းကြပ်ရမည့် ဝင်ငွေသည် %s အဆင့်သို့ ရောက်ပြီး %s သက်သာပါသည်။",
+		"advice_lowest":            "%s: အခွန်စည်းကြပ်ရမည့် ဝင်ငွေသည် အနိမ့်ဆုံး အဆင့်တွင် ရှိပြီး ဖြစ်ပါသည်။",
+		"household_title":          "🏠 မိသားစု အခွန်",
+		"household_total":          "မိသားစု",
+		"household_claims":         "တောင်းဆိုသော မှီခိုသူများ",
+		"household_best":           "မှီခိုသူများကို အခွန်အနည်းဆုံး ပေးရသည့် ပုံစံဖြင့် တောင်းဆိုထားပါသည်။",
+		"household_spouse_dropped": "%s သည် အိမ်ထောင်ဖက် သက်သာခွင့်ကို တောင်းဆိုထားသော်လည်း ဇနီးမောင်နှံ နှစ်ဦးစလုံး ဝင်ငွေရှိသဖြင့် ထည့်မတွက်ပါ။",
+		"cli_err_household":        "သိမ်းထားသော တွက်ချက်မှုများတွင် မှီခိုသူ အရေအတွက်ကိုသာ ဖော်ပြထားသဖြင့် မိသားစု၏ မှီခိုသူများကို မသိနိုင်ပါ။ --parents နှင့် --children ကို ထည့်ပါ။",
+		"res_history":              "📜 ယခင် တွက်ချက်မှုများ",
+		"res_gross_income":         "နှစ်စဉ် စုစုပေါင်း ဝင်ငွေ",
+		"res_basic_relief":         "အခြေခံ (၂၀% အများဆုံး သိန်း ၁၀၀)",
+		"res_parent_relief":        "မိဘ",
+		"res_spouse_relief":        "အိမ်ထောင်ဖက်",
+		"res_child_relief":         "ကလေး",
+		"res_ssb_relief":           "လူမှုဖူလုံရေး",
+		"res_brackets":             "📈 အခွန်နှုန်း အဆင့်လိုက် ခွဲခြမ်းစိတ်ဖြာချက်",
+		"res_from":                 "မှ",
+		"res_to":                   "အထိ",
+		"res_tax_amount":           "အခွန်ပမာဏ",
+		"res_and_above":            "နှင့်အထက်",
+		"res_item":                 "အကြောင်းအရာ",
+		"res_amount":               "ပမာဏ",
+		"start_month_prompt":       "စတင်သည့် လ",
+		"fiscal_year_prompt":       "ဘဏ္ဍာနှစ်",
+		"start_month_desc":         "ဘဏ္ဍာနှစ်အတွင်း အလုပ်စတင်သည့် လ",
+		"end_month_prompt":         "ပြီးဆုံးသည့် လ",
+		"end_month_desc":           "နောက်ဆုံး အလုပ်လုပ်သည့် လ၊ နှစ်ကုန်အထိ ဆက်လုပ်ပါက နှစ်၏ နောက်ဆုံးလ ကို ထားပါ",
+		"err_end_month":            "ပြီးဆုံးသည့် လသည် စတင်သည့် လ မတိုင်မီ မဖြစ်ရပါ",
+		"err_month_year":           "ဤလသည် ဘဏ္ဍာနှစ်အတွင်း မပါဝင်ပါ",
+		"chart_brackets":           "📊 အခွန်နှုန်းအဆင့်အလိုက် အခွန်",
+		"chart_sensitivity":        "📈 ဝင်ငွေအလိုက် အခွန် (လစာ၏ ၅၀%–၁၅၀%)",
+		"chart_total_tax":          "စုစုပေါင်း အခွန်",
+		"chart_effective":          "ပျမ်းမျှ အခွန်နှုန်း",
+		"chart_you":                "သင့်လစာ",
+		"res_months":               "တွက်ချက်သည့် လအရေအတွက်",
+		"fiscal_year":              "%s ဘဏ္ဍာနှစ်",
+		"fiscal_year_interim":      "%s ကြားကာလ ဘဏ္ဍာနှစ်",
+		"proration_calendar":       "%s (%d/%d ရက်)",
+		"proration_working":        "%s (အလုပ်ရက် %d/%d)",
+		"res_dependents":           "👪 မှီခိုသူများ",
+		"res_dependents_decision":  "ဆုံးဖြတ်ချက်",
+		"res_dependents_relief":    "မှီခိုသူ သက်သာခွင့်များ",
+		"dep_parent":               "မိဘ",
+		"dep_spouse":               "အိမ်ထောင်ဖက်",
+		"dep_child":                "သားသမီး",
+		"dep_other":                "မသိ",
+		"dep_accepted":             "လက်ခံ: %s",
+		"dep_rejected":             "ပယ်ချ: %s",
+		"dep_child_minor":          "အသက် ၁၈ နှစ်အောက်",
+		"dep_child_student":        "အချိန်ပြည့် ကျောင်းသား",
+		"dep_child_disabled":       "မသန်စွမ်း",
+		"dep_child_adult":          "အသက် ၁၈ နှစ်နှင့်အထက်ဖြစ်ပြီး ကျောင်းသား သို့မဟုတ် မသန်စွမ်းသူ မဟုတ်",
+		"dep_child_no_birth_date":  "အသက်ကန့်သတ်ချက် စစ်ရန် မွေးသက္ကရာဇ် မရှိ",
+		"dep_child_no_fiscal_year": "အသက်ကန့်သတ်ချက် စစ်ရန် ဘဏ္ဍာနှစ် မရှိ",
+		"dep_parent_lives_with":    "အခွန်ထမ်းနှင့် အတူနေ",
+		"dep_parent_apart":         "အခွန်ထမ်းနှင့် အတူမနေ",
+		"dep_parent_limit":         "မိဘ ၂ ဦးထက် ပိုမတောင်းဆိုနိုင်",
+		"dep_spouse_no_income":     "ဝင်ငွေ မရှိ",
+		"dep_spouse_earns":         "ဝင်ငွေ ရှိ",
+		"dep_spouse_limit":         "အိမ်ထောင်ဖက် တစ်ဦးသာ တောင်းဆိုနိုင်",
+		"dep_unknown_relationship": "မိဘ၊ အိမ်ထောင်ဖက် သို့မဟုတ် သားသမီး မဟုတ်",
+		"cli_err_dependents":       "မှီခိုသူများကို ဖတ်၍ မရပါ: %v",
+		"cli_dependents":           "မှီခိုသူများ",
+		"dependents_listed_desc":   "အပြည့်အစုံ စာရင်းသွင်းထားပြီး တွက်ချက်ရာတွင် တစ်ဦးချင်း အရည်အချင်း စစ်ဆေးပါမည်။",
+		"explain_title":            "🧮 မည်သို့ တွက်ချက်ထားသနည်း?",
+		"explain_footer":           "b: နောက်သို့ • q: ထွက်မည်",
+		"explain_step":             "အဆင့်",
+		"explain_working":          "တွက်ပုံ",
+		"explain_months":           "%s မှ %s ထိ",
+		"explain_proration":        "%s (လမပြည့် အလုပ်လုပ်)",
+		"explain_proration_rule":   "%s × %d/%d ရက်",
+		"explain_gross":            "တစ်လ %s × %d",
+		"explain_gross_part":       "တစ်လ %s × %d နှင့် အထက်ပါ လမပြည့်သော လများ",
+		"explain_basic":            "အခြေခံ သက်သာခွင့်",
+		"explain_basic_rule":       "%s နှုန်း၊ %s ပေါ်တွင်၊ အများဆုံး %s",
+		"explain_basic_capped":     "%s နှုန်း၊ %s ပေါ်တွင်၊ %s ဖြင့် ကန့်သတ်",
+		"explain_parent":           "မိဘ သက်သာခွင့်",
+		"explain_parent_rule":      "%d × %s၊ အတူနေသော မိဘ အများဆုံး %d ဦးအတွက်",
+		"explain_spouse":           "အိမ်ထောင်ဖက် သက်သာခွင့်",
+		"explain_spouse_rule":      "%d × %s၊ ဝင်ငွေမရှိသော အိမ်ထောင်ဖက်အတွက်",
+		"explain_child":            "သားသမီး သက်သာခွင့်",
+		"explain_child_rule":       "%d × %s၊ အသက် ၁၈ နှစ်အောက်၊ ကျောင်းတက်နေသော သို့မဟုတ် မသန်စွမ်းသော သားသမီး တစ်ဦးစီအတွက်",
+		"explain_ssb":              "လူမှုဖူလုံရေး ထည့်ဝင်ငွေ",
+		"explain_ssb_rule":         "ထည့်ဝင်သည့်အတိုင်း နုတ်ယူ",
+		"explain_total_relief":     "အထက်ပါ သက်သာခွင့်များ ပေါင်းလဒ်",
+		"explain_taxable":          "%s − %s",
+		"explain_taxable_zero":     "%s − %s၊ သုညဟု ယူဆ",
+		"explain_bracket":          "%s မှ %s ထိ အခွန်",
+		"explain_bracket_top":      "%s အထက် အခွန်",
+		"explain_bracket_rule":     "%s နှုန်း၊ %s ပေါ်တွင်",
+		"explain_total_tax":        "အဆင့်အလိုက် အခွန်များ ပေါင်းလဒ်",
+		"err_no_config":            "ဆက်တင် ဖိုင်တွဲ မရှိပါ",
+		"err_copy":                 "ကူးယူ၍ မရပါ",
+		"err_prefix":               "အမှား: ",
+		"error_footer":             "r: ပြင်ဆင်မည် • n: အသစ်တွက်မည် • h: မှတ်တမ်း • l: ဘာသာစကား • q: ထွက်မည်",
+		"format_txt":               "TXT စာရွက်",
+		"format_json":              "JSON ဒေတာ",
+		"format_csv":               "CSV ဇယား",
+		"format_md":                "Markdown",
+		"format_html":              "HTML စာမျက်နှာ",
+		"format_pdf":               "PDF စာရွက်",
+		"format_xlsx":              "Excel ဇယားစာအုပ်",
+		"format_template":          "စိတ်ကြိုက် ပုံစံ",
+		"cli_title":                "🇲🇲 မြန်မာ ဝင်ငွေခွန် တွက်စက် (CLI)",
+		"cli_income_prompt":        "လစဉ် ဝင်ငွေ ထည့်ပါ (ကျပ်): ",
+		"cli_start_prompt":         "စတင်သည့် လ ထည့်ပါ (1 = ဇန်နဝါရီ, 2 = ဖေဖော်ဝါရီ, ..., 12 = ဒီဇင်ဘာ): ",
+		"cli_end_prompt":           "ပြီးဆုံးသည့် လ ထည့်ပါ (1-12, နှစ်ကုန်အထိ လုပ်ကိုင်ပါက 3 = မတ်): ",
+		"cli_parents_prompt":       "မှီခိုသော မိဘ အရေအတွက် ထည့်ပါ (တစ်ဦးလျှင် ၁,၀၀၀,၀၀၀ ကျပ်): ",
+		"cli_spouse_prompt":        "မှီခိုသော အိမ်ထောင်ဖက် ရှိပါသလား? (1 = ရှိ, 0 = မရှိ): ",
+		"cli_children_prompt":      "သားသမီး အရေအတွက် ထည့်ပါ (တစ်ဦးလျှင် ၅၀၀,၀၀၀ ကျပ်): ",
+		"cli_ssb_prompt":           "တစ်နှစ်စာ လူမှုဖူလုံရေး ထည့်ဝင်ငွေ စုစုပေါင်း ထည့်ပါ (ကျပ်): ",
+		"cli_err_invalid":          "ထည့်သွင်းမှု မမှန်ကန်ပါ၊ ထပ်စမ်းပါ။",
+		"cli_err_income":           "လစဉ် ဝင်ငွေသည် 0 ထက် ကြီးရပါမည်။",
+		"cli_err_start":            "စတင်သည့် လသည် 1 မှ 12 အတွင်း ဖြစ်ရပါမည်။",
+		"cli_err_end":              "ပြီးဆုံးသည့် လသည် 1 မှ 12 အတွင်း ဖြစ်ရပါမည်။",
+		"cli_err_end_before":       "ဧပြီ–မတ် ဘဏ္ဍာနှစ်တွင် ပြီးဆုံးသည့် လသည် စတင်သည့် လ မတိုင်မီ မဖြစ်ရပါ။",
+		"cli_err_end_before_fy":    "%s တွင် ပြီးဆုံးသည့် လသည် စတင်သည့် လ မတိုင်မီ မဖြစ်ရပါ။",
+		"cli_err_month_year":       "ဤလသည် %s အတွင်း မပါဝင်ပါ။",
+		"cli_end_prompt_fy":        "ပြီးဆုံးသည့် လ ထည့်ပါ (1-12, နှစ်ကုန်အထိ လုပ်ကိုင်ပါက %d = %s): ",
+		"cli_err_fy":               "ဘဏ္ဍာနှစ် %q ကို မသိပါ။ ဥပမာ 2026-27 ဟု ထည့်ပါ။",
+		"cli_err_date":             "ရက်စွဲ %q မမှန်ကန်ပါ။ YYYY-MM-DD ပုံစံ သုံးပါ။",
+		"cli_err_prorate":          "အချိုးကျ တွက်နည်း %q ကို မသိပါ။ calendar သို့မဟုတ် working ဟု ထည့်ပါ။",
+		"cli_err_prorate_dates":    "--prorate အတွက် --joined သို့မဟုတ် --left လိုအပ်ပါသည်။",
+		"cli_err_employment":       "အလုပ်ဝင်ရက်နှင့် ထွက်ရက်သည် %s အတွင်း မရှိပါ။",
+		"cli_err_parents_negative": "မှီခိုသော မိဘ အရေအတွက်သည် အနုတ် မဖြစ်ရပါ။",
+		"cli_err_parents_max":      "မှီခိုသော မိဘ အရေအတွက်သည် %d ထက် မပိုရပါ။",
+		"cli_err_spouse":           "ထည့်သွင်းမှု မမှန်ကန်ပါ။ ရှိလျှင် 1၊ မရှိလျှင် 0 ထည့်ပါ။",
+		"cli_err_children":         "သားသမီး အရေအတွက်သည် အနုတ် မဖြစ်ရပါ။",
+		"cli_err_ssb":              "တစ်နှစ်စာ လူမှုဖူလုံရေး ထည့်ဝင်ငွေသည် အနုတ် မဖြစ်ရပါ။",
+		"cli_err_lang":             "ဘာသာစကား %q ကို မပံ့ပိုးပါ။",
+		"cli_err_calc":             "ဝင်ငွေခွန် တွက်ချက်ရာတွင် အမှား: %v",
+		"calc_income":              "လစဉ် ဝင်ငွေသည် ၀ ထက် ကြီးရပါမည်",
+		"calc_proration":           "အချိုးကျ တွက်နည်းကို မသိပါ။ calendar သို့မဟုတ် working ဟု ထည့်ပါ",
+		"calc_start_month":         "စတင်သည့် လသည် ၁ မှ ၁၂ အတွင်း ဖြစ်ရပါမည်",
+		"calc_end_month":           "ပြီးဆုံးသည့် လသည် ၁ မှ ၁၂ အတွင်း ဖြစ်ရပါမည်",
+		"calc_parents_negative":    "မှီခိုသော မိဘ အရေအတွက်သည် အနုတ် မဖြစ်ရပါ",
+		"calc_parents_max":         "မှီခိုသော မိဘ အရေအတွက်သည် %d ထက် မပိုရပါ",
+		"calc_spouse":              "မှီခိုသော အိမ်ထောင်ဖက်သည် ၀ သို့မဟုတ် ၁ သာ ဖြစ်ရပါမည်",
+		"calc_children":            "သားသမီး အရေအတွက်သည် အနုတ် မဖြစ်ရပါ",
+		"calc_ssb":                 "တစ်နှစ်စာ လူမှုဖူလုံရေး ထည့်ဝင်ငွေသည် အနုတ် မဖြစ်ရပါ",
+		"calc_no_fy":               "အလုပ်ဝင်ရက်နှင့် ထွက်ရက်အတွက် ဘဏ္ဍာနှစ် လိုအပ်ပါသည်",
+		"calc_not_employed":        "အလုပ်ဝင်ရက်နှင့် ထွက်ရက်သည် ဘဏ္ဍာနှစ်အတွင်း မရှိပါ",
+		"calc_household_negative":  "မိသားစု မှီခိုသူ အရေအတွက်သည် အနုတ် မဖြစ်ရပါ",
+		"calc_household_parents":   "ဇနီးမောင်နှံ နှစ်ဦးပေါင်း မိဘ %d ဦးထက် ပို၍ မတောင်းဆိုနိုင်ပါ",
+		"calc_household_claims":    "ဇနီးမောင်နှံ တောင်းဆိုသော မိဘ သို့မဟုတ် သားသမီး အရေအတွက်သည် မိသားစုတွင် ရှိသည်ထက် များနေပါသည်",
+		"calc_earning_spouse":      "ဝင်ငွေရှိသော အိမ်ထောင်ဖက်ကို မှီခိုသော အိမ်ထောင်ဖက်အဖြစ် မတောင်းဆိုနိုင်ပါ",
+		"calc_listed_twice":        "အိမ်ထောင်ဖက် %d သည် %s ကို နှစ်ကြိမ် ဖော်ပြထားသည်",
+		"calc_claimed_twice":       "%s ကို ဇနီးမောင်နှံ နှစ်ဦးစလုံးက တောင်းဆိုထားသည်",
+		"cli_err_template":         "ပုံစံ အသုံးပြုမှု မအောင်မြင်ပါ: %v",
+		"cli_using_profile":        "👤 ပရိုဖိုင် %s ကို အသုံးပြုနေသည်",
+		"cli_total_tax":            "ကိုယ်ပိုင် ဝင်ငွေခွန် စုစုပေါင်း",
+		"cli_above":                "%s အထက်",
+		"cli_up_to":                "%s အထိ",
+		"cli_history_saved":        "🗂️  မှတ်တမ်းတွင် #%d အဖြစ် သိမ်းပြီးပါပြီ",
+		"cli_history_header":       "အမှတ်\tရက်စွဲ\tစုစုပေါင်း ဝင်ငွေ\tစုစုပေါင်း အခွန်\tစည်းမျဉ်း\tအမည်",
+		"cli_history_unknown":      "အမှတ် သို့မဟုတ် အမည် %q ဖြင့် သိမ်းထားသော တွက်ချက်မှု မရှိပါ။",
+		"cli_history_ambiguous":    "%q အမည်ဖြင့် သိမ်းထားသော တွက်ချက်မှု တစ်ခုထက်ပို၍ ရှိသည် (%s)၊ အမှတ်ကို ထည့်ပါ။",
+		"cli_history_not_found":    "မှတ်တမ်း #%d ကို မတွေ့ပါ။",
+		"cli_rules":                "စည်းမျဉ်း",
+		"cli_profile_empty":        "သိမ်းထားသော ပရိုဖိုင် မရှိပါ။",
+		"cli_profile_header":       "အမည်\tဝန်ထမ်း အမှတ်\tမိဘ\tအိမ်ထောင်ဖက်\tသားသမီး\tလူမှုဖူလုံရေး",
+		"cli_profile_not_found":    "ပရိုဖိုင် %q ကို မတွေ့ပါ။",
+		"cli_profile_saved":        "👤 ပရိုဖိုင် %s ကို သိမ်းပြီးပါပြီ",
+		"cli_profile_deleted":      "🗑️  ပရိုဖိုင် %s ကို ဖျက်ပြီးပါပြီ",
+		"cli_name":                 "အမည်",
+		"cli_employee_id":          "ဝန်ထမ်း အမှတ်",
+		"cli_language":             "ဘာသာစကား",
+		"cli_yes":                  "ဟုတ်",
+		"cli_no":                   "မဟုတ်",
+		"cli_lang_header":          "ကုဒ်\tအမည်\tဘာသာပြန်ပြီး",
+		"cli_lang_complete":        "စာသားအားလုံး ဘာသာပြန်ပြီးပါပြီ",
+		"month_1":                  "ဇန်နဝါရီ",
+		"month_2":                  "ဖေဖော်ဝါရီ",
+		"month_3":                  "မတ်",
+		"month_4":                  "ဧပြီ",
+		"month_5":                  "မေ",
+		"month_6":                  "ဇွန်",
+		"month_7":                  "ဇူလိုင်",
+		"month_8":                  "ဩဂုတ်",
+		"month_9":                  "စက်တင်ဘာ",
+		"month_10":                 "အောက်တိုဘာ",
+		"month_11":                 "နိုဝင်ဘာ",
+		"month_12":                 "ဒီဇင်ဘာ",
+	},
+}
+
+// plurals holds the built-in strings that depend on a count, by CLDR plural
+// category ("one", "other", ...). The count is the first argument to Tf.
+var plurals = map[Lang]map[string]map[string]string{
+	EN: {
+		"history_count":         {"one": "%d saved calculation", "other": "%d saved calculations"},
+		"compare_count":         {"one": "%d scenario", "other": "%d scenarios"},
+		"cli_lang_untranslated": {"one": "%d untranslated string", "other": "%d untranslated strings"},
+		"cli_lang_unknown":      {"one": "%d string not in English", "other": "%d strings not in English"},
+		"advice_parents":        {"one": "%d parent", "other": "%d parents"},
+		"advice_children":       {"one": "%d child", "other": "%d children"},
+	},
+	MY: {
+		"history_count":         {"other": "သိမ်းထားသော တွက်ချက်မှု %d ခု"},
+		"compare_count":         {"other": "အခြေအနေ %d ခု"},
+		"cli_lang_untranslated": {"other": "ဘာသာမပြန်ရသေးသော စာသား %d ခု"},
+		"cli_lang_unknown":      {"other": "အင်္ဂလိပ်တွင် မရှိသော စာသား %d ခု"},
+		"advice_parents":        {"other": "မိဘ %d ဦး"},
+		"advice_children":       {"other": "သားသမီး %d ဦး"},
+	},
+}
+
+// T returns the string with the given id in the requested language, falling
+// back to English and then to the id itself when it is not translated. A
+// plural string is returned in its "other" form.
+func T(lang Lang, id string) string {
+	l := lookup(lang, id)
+	if s, ok := messages[l][id]; ok {
+		return s
+	}
+	if forms, ok := plurals[l][id]; ok {
+		return forms["other"]
+	}
+	return id
+}
+
+// Tf formats the string with the given id, which holds fmt verbs, with args.
+// Numbers are written in the language's digits, and a plural string picks
+// the form matching its first argument. Like T, it falls back to English.
+func Tf(lang Lang, id string, args ...any) string {
+	p, ok := printers[lookup(lang, id)]
+	if !ok {
+		return id
+	}
+	return p.Sprintf(id, args...)
+}
+
+// MonthName returns the name of a calendar month (1 = January) in the
+// requested language.
+func MonthName(lang Lang, month int64) string {
+	return T(lang, fmt.Sprintf("month_%d", month))
+}
+
+// ParseLang parses a language code such as "EN" or "my", or a POSIX locale
+// such as "my_MM.UTF-8". It reports false for languages that are neither
+// built in nor loaded.
+func ParseLang(s string) (Lang, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "_-.@"); i >= 0 {
+		s = s[:i]
+	}
+	l := Lang(strings.ToUpper(s))
+	if _, ok := messages[l]; !ok || s == "" {
+		return "", false
+	}
+	return l, true
+}
+
+// localeVars are the environment variables naming the message locale, in
+// POSIX precedence order.
+var localeVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Detect returns the language of the user's locale. The first locale
+// variable that is set decides, so LC_ALL=C hides LANG=my_MM.
+func Detect(getenv func(string) string) (Lang, bool) {
+	for _, name := range localeVars {
+		if v := getenv(name); v != "" {
+			return ParseLang(v)
+		}
+	}
+	return "", false
+}
+
+// Preferred returns the first supported language among the given choices,
+// in priority order (e.g. a command-line flag, then a saved setting), and
+// falls back to the locale. Empty choices are skipped.
+func Preferred(getenv func(string) string, choices ...string) (Lang, bool) {
+	for _, c := range choices {
+		if l, ok := ParseLang(c); ok {
+			return l, true
+		}
+	}
+	return Detect(getenv)
+}
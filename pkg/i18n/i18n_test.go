@@ -0,0 +1,254 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Lang
+		ok   bool
+	}{
+		{"EN", EN, true},
+		{"my", MY, true},
+		{" MY ", MY, true},
+		{"my_MM.UTF-8", MY, true},
+		{"en_US.UTF-8", EN, true},
+		{"en-GB", EN, true},
+		{"fr_FR.UTF-8", "", false},
+		{"C", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLang(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseLang(%q): expected %q, %t, got %q, %t", tt.in, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestPreferred(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		choices []string
+		want    Lang
+		ok      bool
+	}{
+		{"flag wins", map[string]string{"LANG": "my_MM.UTF-8"}, []string{"EN", "MY"}, EN, true},
+		{"saved setting", map[string]string{"LANG": "en_US.UTF-8"}, []string{"", "MY"}, MY, true},
+		{"invalid choice skipped", nil, []string{"fr", "MY"}, MY, true},
+		{"LANG", map[string]string{"LANG": "my_MM.UTF-8"}, nil, MY, true},
+		{"LC_ALL overrides LANG", map[string]string{"LC_ALL": "en_US.UTF-8", "LANG": "my_MM"}, nil, EN, true},
+		{"LC_MESSAGES", map[string]string{"LC_MESSAGES": "my_MM", "LANG": "en_US"}, nil, MY, true},
+		{"C locale", map[string]string{"LC_ALL": "C", "LANG": "my_MM"}, nil, "", false},
+		{"unknown", nil, nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			got, ok := Preferred(getenv, tt.choices...)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("expected %q, %t, got %q, %t", tt.want, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		lang   Lang
+		units  bool
+		amount float64
+		want   string
+	}{
+		{EN, false, 1200000, "1,200,000.00 MMK"},
+		{EN, true, 1200000, "1,200,000.00 MMK"},
+		{MY, false, 1200000, "၁,၂၀၀,၀၀၀.၀၀ ကျပ်"},
+		{MY, false, -5000.5, "-၅,၀၀၀.၅၀ ကျပ်"},
+		{MY, true, 0, "၀ ကျပ်"},
+		{MY, true, 80000, "၈၀,၀၀၀ ကျပ်"},
+		{MY, true, 1200000, "၁၂ သိန်း"},
+		{MY, true, 7612345.67, "၇၆ သိန်း ၁၂,၃၄၅.၆၇ ကျပ်"},
+		{MY, true, 25000000, "၂ ကုဋေ ၅၀ သိန်း"},
+		{MY, true, 1230000000, "၁၂၃ ကုဋေ"},
+		{MY, true, -150000, "-၁ သိန်း ၅၀,၀၀၀ ကျပ်"},
+	}
+	defer SetTraditionalUnits(false)
+	for _, tt := range tests {
+		SetTraditionalUnits(tt.units)
+		if got := FormatCurrency(tt.lang, tt.amount); got != tt.want {
+			t.Errorf("FormatCurrency(%s, %v) with units %t: expected %q, got %q", tt.lang, tt.amount, tt.units, tt.want, got)
+		}
+	}
+}
+
+func TestDigits(t *testing.T) {
+	if got, want := Digits(MY, "Rate 25.5%"), "Rate ၂၅.၅%"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := Digits(EN, "25"), "25"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"500000", 500000, false},
+		{"1,234.56", 1234.56, false},
+		{" -1000 ", -1000, false},
+		{"၅၀၀၀၀၀", 500000, false},
+		{"၁,၂၀၀,၀၀၀.၅၀", 1200000.5, false},
+		{"5 lakh", 500000, false},
+		{"5lakhs", 500000, false},
+		{"5 သိန်း", 500000, false},
+		{"၅ သိန်း", 500000, false},
+		{"သိန်း ၅၀", 5000000, false},
+		{"1.5 crore", 15000000, false},
+		{"၂ ကုဋေ", 20000000, false},
+		{"၁ ကုဋေ ၂၀ သိန်း ၁၂,၃၄၅.၆၇ ကျပ်", 12012345.67, false},
+		{"1.1 lakh", 110000, false},
+		{"500k", 500000, false},
+		{"72K", 72000, false},
+		{"1.2M", 1200000, false},
+		{"1,000,000 MMK", 1000000, false},
+		{"", 0, true},
+		{"abc123", 0, true},
+		{"lakh", 0, true},
+		{"5 5", 0, true},
+		{"5 lakh crore", 0, true},
+		{"1e6", 0, true},
+		{"5-3", 0, true},
+		{"NaN", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q): expected error, got %v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q): unexpected error: %v", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("ParseAmount(%q): expected %v, got %v", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestParseAmount_RoundTrip(t *testing.T) {
+	defer SetTraditionalUnits(false)
+	for _, units := range []bool{false, true} {
+		SetTraditionalUnits(units)
+		for _, v := range []float64{0, 80000, 1200000, 7612345.67, 25000000} {
+			s := FormatCurrency(MY, v)
+			if got, err := ParseAmount(s); err != nil || got != v {
+				t.Errorf("ParseAmount(%q): expected %v, got %v, %v", s, v, got, err)
+			}
+		}
+	}
+}
+
+// TestCatalogComplete fails when a built-in language lacks a string that
+// English has, or has one English lacks.
+func TestCatalogComplete(t *testing.T) {
+	for _, l := range builtin {
+		for _, id := range Untranslated(l) {
+			t.Errorf("%s: %q is not translated", l, id)
+		}
+		for _, id := range Unknown(l) {
+			t.Errorf("%s: %q is not in English", l, id)
+		}
+		for id := range plurals[EN] {
+			if _, ok := plurals[l][id]; !ok {
+				t.Errorf("%s: %q is not a plural string", l, id)
+			}
+		}
+	}
+}
+
+func TestTf(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		id   string
+		args []any
+		want string
+	}{
+		{EN, "history_count", []any{1}, "1 saved calculation"},
+		{EN, "history_count", []any{12}, "12 saved calculations"},
+		{MY, "history_count", []any{12}, "သိမ်းထားသော တွက်ချက်မှု ၁၂ ခု"},
+		{EN, "cli_profile_not_found", []any{"Aye"}, `Profile "Aye" not found.`},
+		{"XX", "cli_profile_not_found", []any{"Aye"}, `Profile "Aye" not found.`},
+		{MY, "no_such_string", nil, "no_such_string"},
+	}
+	for _, tt := range tests {
+		if got := Tf(tt.lang, tt.id, tt.args...); got != tt.want {
+			t.Errorf("Tf(%s, %q): expected %q, got %q", tt.lang, tt.id, tt.want, got)
+		}
+	}
+	if got := T(MY, "res_basic_relief"); got != "အခြေခံ (၂၀% အများဆုံး သိန်း ၁၀၀)" {
+		t.Errorf("T kept literal percent signs: got %q", got)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"shn.json": `{"lang": "shn", "name": "တႆး", "messages": {
+			"title": "Shan title",
+			"titel": "misspelt",
+			"history_count": {"other": "%d Shan"}
+		}}`,
+		"bad.json":    `{"lang": "sh n", "messages": {}}`,
+		"notes.txt":   `not a catalogue`,
+		"plural.json": `{"lang": "mnw", "messages": {"history_count": {"one": "%d"}}}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := LoadDir(dir)
+	if err == nil {
+		t.Fatal("expected errors for bad.json and plural.json")
+	}
+	if !Available("SHN") || Available("MNW") {
+		t.Fatalf("expected only SHN to load, got %v", Languages())
+	}
+	if l, ok := ParseLang("shn_MM.UTF-8"); !ok || l != "SHN" {
+		t.Errorf("ParseLang: expected SHN, got %q, %t", l, ok)
+	}
+	if got := Name("SHN"); got != "တႆး" {
+		t.Errorf("Name: expected %q, got %q", "တႆး", got)
+	}
+	if got := T("SHN", "title"); got != "Shan title" {
+		t.Errorf("T: expected %q, got %q", "Shan title", got)
+	}
+	if got, want := T("SHN", "res_final_tax"), T(EN, "res_final_tax"); got != want {
+		t.Errorf("T fallback: expected %q, got %q", want, got)
+	}
+	if got := Tf("SHN", "history_count", 3); got != "3 Shan" {
+		t.Errorf("Tf: expected %q, got %q", "3 Shan", got)
+	}
+	if got, want := len(Untranslated("SHN")), len(IDs())-2; got != want {
+		t.Errorf("Untranslated: expected %d ids, got %d", want, got)
+	}
+	if got := Unknown("SHN"); len(got) != 1 || got[0] != "titel" {
+		t.Errorf("Unknown: expected [titel], got %v", got)
+	}
+	if langs := Languages(); len(langs) < 3 || langs[0] != EN || langs[1] != MY {
+		t.Errorf("Languages: expected EN and MY first, got %v", langs)
+	}
+	if err := LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("LoadDir of a missing directory: unexpected error: %v", err)
+	}
+}
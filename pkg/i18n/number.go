@@ -0,0 +1,191 @@
+package i18n
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// myanmarDigits are the Myanmar digits zero to nine (U+1040–U+1049).
+var myanmarDigits = []rune("၀၁၂၃၄၅၆၇၈၉")
+
+// Amounts of kyat in the traditional Burmese units.
+const (
+	lakh  = 100_000
+	crore = 100 * lakh
+)
+
+// traditionalUnits selects lakh and crore for Burmese amounts. It is set
+// once at startup from the user's configuration.
+var traditionalUnits bool
+
+// SetTraditionalUnits chooses whether Burmese amounts are written in lakh
+// (သိန်း) and crore (ကုဋေ) rather than with thousands separators.
+func SetTraditionalUnits(enabled bool) {
+	traditionalUnits = enabled
+}
+
+// TraditionalUnits reports whether Burmese amounts use lakh and crore.
+func TraditionalUnits() bool {
+	return traditionalUnits
+}
+
+// Digits writes the ASCII digits in s with the language's digits. Other
+// characters, such as separators and the decimal point, are kept.
+func Digits(lang Lang, s string) string {
+	if lang != MY {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return myanmarDigits[r-'0']
+		}
+		return r
+	}, s)
+}
+
+// Number formats a number with thousands separators and the given number of
+// decimals in the language's digits.
+func Number(lang Lang, v float64, decimals int) string {
+	return Digits(lang, message.NewPrinter(language.English).Sprintf("%.*f", decimals, v))
+}
+
+// FormatCurrency formats an amount of kyat for display, e.g.
+// "1,200,000.00 MMK" in English and "၁,၂၀၀,၀၀၀.၀၀ ကျပ်" in Burmese, or
+// "၁၂ သိန်း" with traditional units.
+func FormatCurrency(lang Lang, amount float64) string {
+	if lang != MY {
+		return Number(EN, amount, 2) + " MMK"
+	}
+	if traditionalUnits {
+		return unitsCurrency(amount)
+	}
+	return Number(MY, amount, 2) + " ကျပ်"
+}
+
+// unitsCurrency writes an amount as crore, lakh and the remaining kyat,
+// omitting parts that are zero, e.g. "၂ ကုဋေ ၅၀ သိန်း ၁၂,၃၄၅ ကျပ်".
+func unitsCurrency(amount float64) string {
+	pyas := math.Round(math.Abs(amount) * 100)
+	kyat := math.Floor(pyas / 100)
+	rest := kyat - math.Floor(kyat/lakh)*lakh + math.Mod(pyas, 100)/100
+
+	var parts []string
+	if c := math.Floor(kyat / crore); c > 0 {
+		parts = append(parts, Number(MY, c, 0)+" ကုဋေ")
+	}
+	if l := math.Floor(math.Mod(kyat, crore) / lakh); l > 0 {
+		parts = append(parts, Number(MY, l, 0)+" သိန်း")
+	}
+	if rest > 0 || len(parts) == 0 {
+		decimals := 0
+		if rest != math.Floor(rest) {
+			decimals = 2
+		}
+		parts = append(parts, Number(MY, rest, decimals)+" ကျပ်")
+	}
+	s := strings.Join(parts, " ")
+	if amount < 0 && pyas > 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// amountUnits are the multipliers accepted after (or, as is common in
+// Burmese, before) a number in ParseAmount.
+var amountUnits = map[string]float64{
+	"k":      1e3,
+	"m":      1e6,
+	"lakh":   lakh,
+	"lakhs":  lakh,
+	"lac":    lakh,
+	"သိန်း":  lakh,
+	"crore":  crore,
+	"crores": crore,
+	"cr":     crore,
+	"ကုဋေ":   crore,
+	"kyat":   1,
+	"mmk":    1,
+	"ကျပ်":   1,
+}
+
+// amountToken splits an amount into numbers and unit words.
+var amountToken = regexp.MustCompile(`\d+(?:\.\d*)?|\.\d+|[^\d\s.]+`)
+
+// ParseAmount parses an amount typed in either language. It accepts Myanmar
+// or Western digits with optional thousands separators, "k" and "M"
+// shorthand, and lakh and crore in English or Burmese, including amounts
+// written by FormatCurrency such as "၁ ကုဋေ ၂၀ သိန်း". A leading minus sign
+// makes the amount negative.
+func ParseAmount(s string) (float64, error) {
+	s = strings.Map(func(r rune) rune {
+		if r >= myanmarDigits[0] && r <= myanmarDigits[9] {
+			return '0' + (r - myanmarDigits[0])
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+	s = strings.ReplaceAll(s, ",", "")
+	sign := 1.0
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	// Every character other than spaces must belong to a number or a unit.
+	tokens := amountToken.FindAllString(s, -1)
+	if len(tokens) == 0 || strings.Join(tokens, "") != strings.Join(strings.Fields(s), "") {
+		return 0, errInvalidAmount
+	}
+
+	// A number takes the unit after it, or the unit before it when the unit
+	// is written first ("သိန်း ၅၀"); a number without a unit is in kyat.
+	var (
+		total  float64
+		number *float64
+		unit   float64
+	)
+	for _, tok := range tokens {
+		if m, ok := amountUnits[tok]; ok {
+			switch {
+			case number != nil:
+				total += *number * m
+				number = nil
+			case unit == 0:
+				unit = m
+			default:
+				return 0, errInvalidAmount
+			}
+			continue
+		}
+		if c := tok[0]; c != '.' && (c < '0' || c > '9') {
+			return 0, errInvalidAmount
+		}
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil || number != nil {
+			return 0, errInvalidAmount
+		}
+		if unit != 0 {
+			total += v * unit
+			unit = 0
+			continue
+		}
+		number = &v
+	}
+	if unit != 0 {
+		return 0, errInvalidAmount
+	}
+	if number != nil {
+		total += *number
+	}
+	return sign * math.Round(total*100) / 100, nil
+}
+
+// errInvalidAmount is returned by ParseAmount for text that is not an
+// amount.
+var errInvalidAmount = errors.New("invalid amount")
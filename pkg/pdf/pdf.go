@@ -0,0 +1,433 @@
+// Package pdf implements a small PDF writer able to lay out shaped Unicode
+// text. Text is shaped with HarfBuzz so complex scripts such as Burmese are
+// rendered with the correct glyph reordering and stacking, and fonts are
+// embedded as CID-keyed TrueType fonts addressed by glyph id.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/go-text/typesetting/di"
+	"github.com/go-text/typesetting/font"
+	"github.com/go-text/typesetting/language"
+	"github.com/go-text/typesetting/shaping"
+	"golang.org/x/image/math/fixed"
+)
+
+// A4 page dimensions in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font is a TrueType font that can be embedded into a Document.
+type Font struct {
+	name  string
+	data  []byte
+	face  *font.Face
+	upem  float64
+	used  map[font.GID][]rune
+	index int
+}
+
+// ParseFont parses a TrueType font file. The name is used as the PostScript
+// base font name inside the generated document.
+func ParseFont(name string, data []byte) (*Font, error) {
+	face, err := font.ParseTTF(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse font %s: %w", name, err)
+	}
+	return &Font{
+		name: name,
+		data: data,
+		face: face,
+		upem: float64(face.Upem()),
+		used: make(map[font.GID][]rune),
+	}, nil
+}
+
+func (f *Font) covers(r rune) bool {
+	_, ok := f.face.NominalGlyph(r)
+	return ok
+}
+
+// Color is an RGB color.
+type Color struct {
+	R, G, B uint8
+}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// Document is a PDF document under construction. Coordinates passed to its
+// drawing methods are in points with the origin at the top-left corner of the
+// page.
+type Document struct {
+	Width  float64
+	Height float64
+
+	fonts   []*Font
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	shaper  shaping.HarfbuzzShaper
+}
+
+// New creates an A4 document. Text is set in the first font that covers each
+// character, so fonts should be given in order of preference.
+func New(fonts ...*Font) *Document {
+	for i, f := range fonts {
+		f.index = i
+	}
+	return &Document{
+		Width:  A4Width,
+		Height: A4Height,
+		fonts:  fonts,
+	}
+}
+
+// AddPage starts a new page. Subsequent drawing goes to this page.
+func (d *Document) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if d.current == nil {
+		d.AddPage()
+	}
+	return d.current
+}
+
+// Rect draws a rectangle filled with fill and, if stroke is non-nil, outlined
+// with stroke. A nil fill leaves the rectangle transparent.
+func (d *Document) Rect(x, y, w, h float64, fill, stroke *Color) {
+	p := d.page()
+	op := ""
+	switch {
+	case fill != nil && stroke != nil:
+		op = "B"
+	case fill != nil:
+		op = "f"
+	case stroke != nil:
+		op = "S"
+	default:
+		return
+	}
+	if fill != nil {
+		fmt.Fprintf(p, "%s rg\n", fill.operands())
+	}
+	if stroke != nil {
+		fmt.Fprintf(p, "%s RG\n", stroke.operands())
+	}
+	fmt.Fprintf(p, "%.2f %.2f %.2f %.2f re %s\n", x, d.Height-y-h, w, h, op)
+}
+
+// Line draws a straight line of the given width and color.
+func (d *Document) Line(x1, y1, x2, y2, width float64, c Color) {
+	p := d.page()
+	fmt.Fprintf(p, "%s RG %.2f w %.2f %.2f m %.2f %.2f l S\n",
+		c.operands(), width, x1, d.Height-y1, x2, d.Height-y2)
+}
+
+// glyph is a positioned glyph of a shaped run, in text space units.
+type glyph struct {
+	font    *Font
+	id      font.GID
+	x, y    float64
+	advance float64
+}
+
+// run is a maximal substring set in a single font.
+type run struct {
+	font       *Font
+	start, end int
+}
+
+// itemize splits text into runs by font coverage. Characters not covered by
+// any font (such as emoji) are dropped.
+func (d *Document) itemize(text []rune) ([]rune, []run) {
+	var (
+		kept []rune
+		runs []run
+	)
+	for _, r := range text {
+		var f *Font
+		if n := len(runs); n > 0 && runs[n-1].font.covers(r) {
+			f = runs[n-1].font
+		} else {
+			for _, candidate := range d.fonts {
+				if candidate.covers(r) {
+					f = candidate
+					break
+				}
+			}
+		}
+		if f == nil {
+			continue
+		}
+		kept = append(kept, r)
+		if n := len(runs); n > 0 && runs[n-1].font == f {
+			runs[n-1].end = len(kept)
+		} else {
+			runs = append(runs, run{font: f, start: len(kept) - 1, end: len(kept)})
+		}
+	}
+	return kept, runs
+}
+
+func scriptOf(text []rune) language.Script {
+	for _, r := range text {
+		if s := language.LookupScript(r); s != language.Common && s != language.Inherited {
+			return s
+		}
+	}
+	return language.Latin
+}
+
+// shape lays out text at the given font size and returns the positioned
+// glyphs along with the total advance width.
+func (d *Document) shape(text string, size float64) ([]glyph, float64) {
+	runes, runs := d.itemize([]rune(text))
+
+	var (
+		glyphs []glyph
+		pen    float64
+	)
+	for _, r := range runs {
+		units := r.font.upem
+		out := d.shaper.Shape(shaping.Input{
+			Text:      runes,
+			RunStart:  r.start,
+			RunEnd:    r.end,
+			Direction: di.DirectionLTR,
+			Face:      r.font.face,
+			Size:      fixed.I(int(units)),
+			Script:    scriptOf(runes[r.start:r.end]),
+			Language:  language.DefaultLanguage(),
+		})
+		scale := size / units
+		cluster := -1
+		for _, g := range out.Glyphs {
+			// Only the first glyph of a cluster carries its characters in
+			// the ToUnicode map, so copied text is not duplicated.
+			var source []rune
+			if g.ClusterIndex != cluster {
+				cluster = g.ClusterIndex
+				source = runes[g.ClusterIndex:min(g.ClusterIndex+g.RuneCount, len(runes))]
+			}
+			if known, ok := r.font.used[g.GlyphID]; !ok || len(known) == 0 {
+				r.font.used[g.GlyphID] = source
+			}
+			glyphs = append(glyphs, glyph{
+				font:    r.font,
+				id:      g.GlyphID,
+				x:       pen + fromFixed(g.XOffset)*scale,
+				y:       fromFixed(g.YOffset) * scale,
+				advance: fromFixed(g.Advance) * scale,
+			})
+			pen += fromFixed(g.Advance) * scale
+		}
+	}
+	return glyphs, pen
+}
+
+func fromFixed(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
+
+// TextWidth returns the advance width of text set at the given size.
+func (d *Document) TextWidth(text string, size float64) float64 {
+	_, width := d.shape(text, size)
+	return width
+}
+
+// Text draws text with its baseline starting at (x, y).
+func (d *Document) Text(x, y, size float64, c Color, text string) {
+	glyphs, _ := d.shape(text, size)
+	if len(glyphs) == 0 {
+		return
+	}
+	p := d.page()
+	fmt.Fprintf(p, "BT %s rg\n", c.operands())
+	var current *Font
+	for _, g := range glyphs {
+		if g.font != current {
+			current = g.font
+			fmt.Fprintf(p, "/F%d %.2f Tf\n", current.index, size)
+		}
+		fmt.Fprintf(p, "1 0 0 1 %.2f %.2f Tm <%04X> Tj\n",
+			x+g.x, d.Height-y+g.y, uint16(g.id))
+	}
+	p.WriteString("ET\n")
+}
+
+// WriteTo serializes the document.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		return 0, errors.New("pdf: document has no pages")
+	}
+
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+	// Object numbers are assigned up front: 1 catalog, 2 page tree, then five
+	// objects per font, then two per page (page and content stream).
+	const fontObjects = 5
+	fontBase := 3
+	pageBase := fontBase + fontObjects*len(d.fonts)
+
+	begin := func() {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+	}
+	end := func() {
+		buf.WriteString("endobj\n")
+	}
+	stream := func(dict string, data []byte) error {
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write(data); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "<< %s /Filter /FlateDecode /Length %d >>\nstream\n", dict, z.Len())
+		buf.Write(z.Bytes())
+		buf.WriteString("\nendstream\n")
+		return nil
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	begin()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\n")
+	end()
+
+	begin()
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageBase+2*i)
+	}
+	fmt.Fprintf(&buf, "<< /Type /Pages /Kids [%s] /Count %d >>\n", strings.Join(kids, " "), len(d.pages))
+	end()
+
+	for i, f := range d.fonts {
+		obj := fontBase + fontObjects*i
+		ext, _ := f.face.FontHExtents()
+		scale := 1000 / f.upem
+
+		begin()
+		fmt.Fprintf(&buf, "<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>\n",
+			f.name, obj+1, obj+4)
+		end()
+
+		begin()
+		fmt.Fprintf(&buf, "<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>\n",
+			f.name, obj+2, f.widths(scale))
+		end()
+
+		begin()
+		fmt.Fprintf(&buf, "<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [0 %.0f 1000 %.0f] /ItalicAngle 0 /Ascent %.0f /Descent %.0f /CapHeight %.0f /StemV 80 /FontFile2 %d 0 R >>\n",
+			f.name, float64(ext.Descender)*scale, float64(ext.Ascender)*scale,
+			float64(ext.Ascender)*scale, float64(ext.Descender)*scale, float64(ext.Ascender)*scale, obj+3)
+		end()
+
+		begin()
+		if err := stream(fmt.Sprintf("/Length1 %d", len(f.data)), f.data); err != nil {
+			return 0, err
+		}
+		end()
+
+		begin()
+		if err := stream("", f.toUnicode()); err != nil {
+			return 0, err
+		}
+		end()
+	}
+
+	fontRefs := make([]string, len(d.fonts))
+	for i := range d.fonts {
+		fontRefs[i] = fmt.Sprintf("/F%d %d 0 R", i, fontBase+fontObjects*i)
+	}
+	for i, content := range d.pages {
+		begin()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents %d 0 R >>\n",
+			d.Width, d.Height, strings.Join(fontRefs, " "), pageBase+2*i+1)
+		end()
+
+		begin()
+		if err := stream("", content.Bytes()); err != nil {
+			return 0, err
+		}
+		end()
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (f *Font) sortedGlyphs() []font.GID {
+	ids := make([]font.GID, 0, len(f.used))
+	for id := range f.used {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// widths renders the /W array for the glyphs used in the document.
+func (f *Font) widths(scale float64) string {
+	var b strings.Builder
+	for _, id := range f.sortedGlyphs() {
+		fmt.Fprintf(&b, "%d [%.0f] ", id, float64(f.face.HorizontalAdvance(id))*scale)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// toUnicode renders a CMap mapping each used glyph back to the characters it
+// was shaped from, so text copied out of the document stays meaningful.
+func (f *Font) toUnicode() []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	var entries []string
+	for _, id := range f.sortedGlyphs() {
+		runes := f.used[id]
+		if len(runes) == 0 {
+			continue
+		}
+		var hex strings.Builder
+		for _, u := range utf16.Encode(runes) {
+			fmt.Fprintf(&hex, "%04X", u)
+		}
+		entries = append(entries, fmt.Sprintf("<%04X> <%s>", uint16(id), hex.String()))
+	}
+	// A bfchar block may hold at most 100 entries.
+	for len(entries) > 0 {
+		n := min(len(entries), 100)
+		fmt.Fprintf(&b, "%d beginbfchar\n%s\nendbfchar\n", n, strings.Join(entries[:n], "\n"))
+		entries = entries[n:]
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func newTestDocument(t *testing.T) *Document {
+	t.Helper()
+	f, err := ParseFont("GoRegular", goregular.TTF)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return New(f)
+}
+
+func TestParseFont_InvalidData(t *testing.T) {
+	if _, err := ParseFont("Broken", []byte("not a font")); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestWriteTo_NoPages(t *testing.T) {
+	doc := newTestDocument(t)
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestWriteTo_Structure(t *testing.T) {
+	doc := newTestDocument(t)
+	doc.AddPage()
+	doc.Text(50, 50, 12, Color{}, "Total Tax: 1,000.00 MMK")
+	doc.Rect(50, 60, 100, 20, &Color{R: 255}, &Color{})
+	doc.Line(50, 90, 150, 90, 1, Color{})
+	doc.AddPage()
+	doc.Text(50, 50, 12, Color{}, "Page two")
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if int(n) != buf.Len() {
+		t.Errorf("expected %d bytes written, got %d", buf.Len(), n)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "%PDF-1.4") {
+		t.Errorf("expected PDF header, got %q", out[:8])
+	}
+	if !strings.HasSuffix(out, "%%EOF\n") {
+		t.Errorf("expected EOF marker at end of document")
+	}
+	if !strings.Contains(out, "/Count 2") {
+		t.Errorf("expected page tree with 2 pages")
+	}
+	if !strings.Contains(out, "/BaseFont /GoRegular") {
+		t.Errorf("expected embedded font GoRegular")
+	}
+
+	// Every xref entry must point at the start of its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	if m == nil {
+		t.Fatalf("startxref not found")
+	}
+	xref, _ := strconv.Atoi(m[1])
+	entries := strings.Split(strings.TrimSpace(out[xref:strings.Index(out, "trailer")]), "\n")[3:]
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[:10])
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !strings.HasPrefix(out[offset:], want) {
+			t.Errorf("xref entry %d: expected %q at offset %d", i+1, want, offset)
+		}
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	doc := newTestDocument(t)
+
+	if w := doc.TextWidth("", 12); w != 0 {
+		t.Errorf("expected empty text width 0, got %f", w)
+	}
+
+	short := doc.TextWidth("100", 12)
+	long := doc.TextWidth("100,000", 12)
+	if short <= 0 || long <= short {
+		t.Errorf("expected 0 < width(100)=%f < width(100,000)=%f", short, long)
+	}
+
+	// Width scales linearly with font size.
+	if double := doc.TextWidth("100", 24); double < 2*short-0.01 || double > 2*short+0.01 {
+		t.Errorf("expected width at 24pt to be %f, got %f", 2*short, double)
+	}
+}
+
+func TestItemize_DropsUncoveredCharacters(t *testing.T) {
+	doc := newTestDocument(t)
+	kept, runs := doc.itemize([]rune("📊 Income"))
+
+	if string(kept) != " Income" {
+		t.Errorf("expected %q, got %q", " Income", string(kept))
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(runs))
+	}
+	if runs[0].start != 0 || runs[0].end != len(kept) {
+		t.Errorf("expected run [0, %d), got [%d, %d)", len(kept), runs[0].start, runs[0].end)
+	}
+}
+
+func TestToUnicode_MapsUsedGlyphs(t *testing.T) {
+	doc := newTestDocument(t)
+	doc.TextWidth("AB", 12)
+
+	cmap := string(doc.fonts[0].toUnicode())
+	if !strings.Contains(cmap, "2 beginbfchar") {
+		t.Errorf("expected 2 bfchar entries, got:\n%s", cmap)
+	}
+	if !strings.Contains(cmap, "<0041>") || !strings.Contains(cmap, "<0042>") {
+		t.Errorf("expected mappings to U+0041 and U+0042, got:\n%s", cmap)
+	}
+}
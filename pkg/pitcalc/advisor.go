@@ -0,0 +1,166 @@
+package pitcalc
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Advice is the result of Advise: the ways of sharing relief between the
+// taxpayers and the contributions that would lower their tax.
+type Advice struct {
+	// Current is each taxpayer's tax as entered, and CurrentTax their total.
+	Current    []float64
+	CurrentTax float64
+	// Allocations lists the ways the dependent parents and children can be
+	// claimed between the taxpayers, lowest total tax first, with the claims
+	// as entered first among equals.
+	Allocations []Allocation
+	// Contributions holds each taxpayer's contribution advice, worked out
+	// for the first (best) allocation.
+	Contributions []Contribution
+}
+
+// Allocation is one way of claiming the dependent parents and children
+// between the taxpayers.
+type Allocation struct {
+	// Inputs are the taxpayers' inputs with the dependents claimed this way,
+	// and Tax the tax each would pay.
+	Inputs   []CalculatePITInput
+	Tax      []float64
+	TotalTax float64
+	// Saving is the tax saved against the claims as entered.
+	Saving float64
+	// Entered marks the claims as entered.
+	Entered bool
+}
+
+// Contribution is what further contributions deducted from income would
+// save one taxpayer.
+type Contribution struct {
+	// SSBRoom is how much more SSB the taxpayer could contribute for the
+	// months counted before reaching SSBMaxMonthly, and SSBSaving the tax
+	// that would save.
+	SSBRoom   float64
+	SSBSaving float64
+	// LowerBracket is the further deduction, such as a life insurance
+	// premium, that would bring the taxable income down into the next lower
+	// bracket, taxed at LowerRate, and BracketSaving the tax that would
+	// save. All three are zero when the income is already in the lowest
+	// bracket.
+	LowerBracket  float64
+	LowerRate     float64
+	BracketSaving float64
+}
+
+// Advise searches the ways one or two taxpayers, such as spouses or
+// siblings, can claim their dependent parents and children, each claimed
+// once and no one claiming more than MaxDependentParents parents, and works
+// out how much voluntary contributions would lower each one's tax. The
+// spouse relief and SSB are kept as entered.
+func Advise(taxpayers ...CalculatePITInput) (*Advice, error) {
+	if len(taxpayers) < 1 || len(taxpayers) > 2 {
+		return nil, fmt.Errorf("advice needs one or two taxpayers, got %d", len(taxpayers))
+	}
+	// Dependents listed in full are shared as the counts found eligible.
+	taxpayers = slices.Clone(taxpayers)
+	for i := range taxpayers {
+		taxpayers[i], _ = taxpayers[i].ClaimDependents()
+	}
+	a := &Advice{}
+	var parents, children int64
+	for i, in := range taxpayers {
+		out, err := CalculatePIT(in)
+		if err != nil {
+			return nil, fmt.Errorf("taxpayer %d: %w", i+1, err)
+		}
+		a.Current = append(a.Current, out.TotalTax)
+		a.CurrentTax += out.TotalTax
+		parents += in.DependentParents
+		children += in.Childrens
+	}
+
+	// The first taxpayer claims p parents and c children, the second the
+	// rest; a single taxpayer claims them all.
+	for p := int64(0); p <= min(parents, MaxDependentParents); p++ {
+		for c := int64(0); c <= children; c++ {
+			claims := [][2]int64{{p, c}, {parents - p, children - c}}
+			if len(taxpayers) == 1 && (p != parents || c != children) || claims[1][0] > MaxDependentParents {
+				continue
+			}
+			alloc := Allocation{Entered: true}
+			for i, in := range taxpayers {
+				alloc.Entered = alloc.Entered && in.DependentParents == claims[i][0] && in.Childrens == claims[i][1]
+				in.DependentParents, in.Childrens = claims[i][0], claims[i][1]
+				tax, err := totalTax(in)
+				if err != nil {
+					return nil, fmt.Errorf("taxpayer %d: %w", i+1, err)
+				}
+				alloc.Inputs = append(alloc.Inputs, in)
+				alloc.Tax = append(alloc.Tax, tax)
+				alloc.TotalTax += tax
+			}
+			alloc.Saving = a.CurrentTax - alloc.TotalTax
+			a.Allocations = append(a.Allocations, alloc)
+		}
+	}
+	sort.SliceStable(a.Allocations, func(i, j int) bool {
+		x, y := a.Allocations[i], a.Allocations[j]
+		if x.TotalTax != y.TotalTax {
+			return x.TotalTax < y.TotalTax
+		}
+		return x.Entered && !y.Entered
+	})
+
+	for _, in := range a.Allocations[0].Inputs {
+		c, err := contribution(in)
+		if err != nil {
+			return nil, err
+		}
+		a.Contributions = append(a.Contributions, c)
+	}
+	return a, nil
+}
+
+// contribution works out the SSB and lower-bracket advice for one taxpayer.
+// Further deductions are added to SSB, which reduces the taxable income
+// like any other deduction.
+func contribution(in CalculatePITInput) (Contribution, error) {
+	out, err := CalculatePIT(in)
+	if err != nil {
+		return Contribution{}, err
+	}
+	var c Contribution
+	saving := func(deduction float64) (float64, error) {
+		more := in
+		more.SSB += deduction
+		tax, err := totalTax(more)
+		return out.TotalTax - tax, err
+	}
+
+	if room := SSBMaxMonthly*float64(in.Months()) - in.SSB; room > 0 {
+		c.SSBRoom = room
+		if c.SSBSaving, err = saving(room); err != nil {
+			return Contribution{}, err
+		}
+	}
+	for i := 1; i < len(brackets); i++ {
+		lower := brackets[i-1]
+		if out.TotalTexable > lower.Limit && out.TotalTexable <= brackets[i].Limit {
+			c.LowerBracket = out.TotalTexable - lower.Limit
+			c.LowerRate = lower.Rate
+			if c.BracketSaving, err = saving(c.LowerBracket); err != nil {
+				return Contribution{}, err
+			}
+		}
+	}
+	return c, nil
+}
+
+func totalTax(in CalculatePITInput) (float64, error) {
+	out, err := CalculatePIT(in)
+	if err != nil {
+		return 0, err
+	}
+	return out.TotalTax, nil
+}
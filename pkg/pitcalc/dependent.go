@@ -0,0 +1,134 @@
+package pitcalc
+
+import "time"
+
+// ChildAgeLimit is the age from which a child is claimed only while a
+// full-time student or when disabled.
+const ChildAgeLimit = 18
+
+// Relationship is how a dependent is related to the taxpayer.
+type Relationship string
+
+const (
+	RelationParent Relationship = "parent"
+	RelationSpouse Relationship = "spouse"
+	RelationChild  Relationship = "child"
+)
+
+// Dependent is a person the taxpayer supports and may claim relief for.
+type Dependent struct {
+	Name         string `json:",omitempty"`
+	Relationship Relationship
+	BirthDate    time.Time `json:",omitzero"`
+	// Student and Disabled let a child over ChildAgeLimit be claimed.
+	Student  bool `json:",omitempty"`
+	Disabled bool `json:",omitempty"`
+	// LivesWith is whether a parent lives with the taxpayer, which the
+	// parent relief requires.
+	LivesWith bool `json:",omitempty"`
+	// HasIncome is whether a spouse earns, which rules out the spouse
+	// relief.
+	HasIncome bool `json:",omitempty"`
+}
+
+// DependentReason says why a dependent was accepted or rejected.
+type DependentReason string
+
+const (
+	ReasonChildMinor          DependentReason = "child_minor"
+	ReasonChildStudent        DependentReason = "child_student"
+	ReasonChildDisabled       DependentReason = "child_disabled"
+	ReasonChildAdult          DependentReason = "child_adult"
+	ReasonChildNoBirthDate    DependentReason = "child_no_birth_date"
+	ReasonChildNoFiscalYear   DependentReason = "child_no_fiscal_year"
+	ReasonParentLivesWith     DependentReason = "parent_lives_with"
+	ReasonParentApart         DependentReason = "parent_apart"
+	ReasonParentLimit         DependentReason = "parent_limit"
+	ReasonSpouseNoIncome      DependentReason = "spouse_no_income"
+	ReasonSpouseEarns         DependentReason = "spouse_earns"
+	ReasonSpouseLimit         DependentReason = "spouse_limit"
+	ReasonUnknownRelationship DependentReason = "unknown_relationship"
+)
+
+// DependentDecision is whether a dependent was accepted for relief, why,
+// and the relief allowed for them.
+type DependentDecision struct {
+	Dependent Dependent
+	Accepted  bool
+	Reason    DependentReason
+	Relief    float64
+}
+
+// ClaimDependents decides which of the input's Dependents are eligible and
+// returns the input with the dependent counts taken from them, and
+// Dependents cleared, with the decision on each. A child's age is taken on
+// the first day of the input's fiscal year, from FiscalYear, Joined or Left,
+// so a child is rejected without a birth date or when the input has no
+// year. An input without Dependents is returned unchanged.
+func (input CalculatePITInput) ClaimDependents() (CalculatePITInput, []DependentDecision) {
+	if len(input.Dependents) == 0 {
+		return input, nil
+	}
+	fy := input.datedYear()
+
+	input.DependentParents, input.DependentSpouse, input.Childrens = 0, 0, 0
+	decisions := make([]DependentDecision, len(input.Dependents))
+	for i, d := range input.Dependents {
+		dec := DependentDecision{Dependent: d}
+		switch d.Relationship {
+		case RelationParent:
+			switch {
+			case !d.LivesWith:
+				dec.Reason = ReasonParentApart
+			case input.DependentParents >= MaxDependentParents:
+				dec.Reason = ReasonParentLimit
+			default:
+				dec.Accepted, dec.Reason, dec.Relief = true, ReasonParentLivesWith, ParentReliefAmount
+				input.DependentParents++
+			}
+		case RelationSpouse:
+			switch {
+			case d.HasIncome:
+				dec.Reason = ReasonSpouseEarns
+			case input.DependentSpouse >= 1:
+				dec.Reason = ReasonSpouseLimit
+			default:
+				dec.Accepted, dec.Reason, dec.Relief = true, ReasonSpouseNoIncome, SpouseReliefAmount
+				input.DependentSpouse++
+			}
+		case RelationChild:
+			switch {
+			case d.BirthDate.IsZero():
+				dec.Reason = ReasonChildNoBirthDate
+			case fy.IsZero():
+				dec.Reason = ReasonChildNoFiscalYear
+			case age(d.BirthDate, fy.Start()) < ChildAgeLimit:
+				dec.Accepted, dec.Reason = true, ReasonChildMinor
+			case d.Student:
+				dec.Accepted, dec.Reason = true, ReasonChildStudent
+			case d.Disabled:
+				dec.Accepted, dec.Reason = true, ReasonChildDisabled
+			default:
+				dec.Reason = ReasonChildAdult
+			}
+			if dec.Accepted {
+				dec.Relief = ChildReliefAmount
+				input.Childrens++
+			}
+		default:
+			dec.Reason = ReasonUnknownRelationship
+		}
+		decisions[i] = dec
+	}
+	input.Dependents = nil
+	return input, decisions
+}
+
+// age returns someone's age in whole years on the given day.
+func age(birth, on time.Time) int {
+	years := on.Year() - birth.Year()
+	if on.Month() < birth.Month() || on.Month() == birth.Month() && on.Day() < birth.Day() {
+		years--
+	}
+	return years
+}
@@ -0,0 +1,54 @@
+package pitcalc
+
+// StepKind identifies a step of the calculation.
+type StepKind string
+
+const (
+	StepMonths       StepKind = "months"
+	StepProration    StepKind = "proration"
+	StepGross        StepKind = "gross"
+	StepBasicRelief  StepKind = "basic_relief"
+	StepParentRelief StepKind = "parent_relief"
+	StepSpouseRelief StepKind = "spouse_relief"
+	StepChildRelief  StepKind = "child_relief"
+	StepSSBRelief    StepKind = "ssb_relief"
+	StepTotalRelief  StepKind = "total_relief"
+	StepTaxable      StepKind = "taxable"
+	StepBracket      StepKind = "bracket"
+	StepTotalTax     StepKind = "total_tax"
+)
+
+// Step is one step of a calculation, with the figures it was worked out
+// from and its result, Amount. Which figures are set depends on Kind:
+//
+//   - StepMonths: Month and LastMonth, the first and last months counted,
+//     and Count, the number of months.
+//   - StepProration: Month, Base, the monthly income, and Count of Of days
+//     employed.
+//   - StepGross: Base, the monthly income, and Count, the months counted in
+//     full; Amount also includes the prorated months before it.
+//   - StepBasicRelief: Rate of Base, the gross income, at most Cap; Capped
+//     when the cap applied.
+//   - StepParentRelief, StepSpouseRelief and StepChildRelief: Count
+//     dependents at Base each, with at most Cap parents or spouses.
+//   - StepSSBRelief: Base, the yearly contribution.
+//   - StepTotalRelief: the sum of the reliefs.
+//   - StepTaxable: Base, the gross income, less Of, the total relief;
+//     Capped when the reliefs exceed the income and it is taken as zero.
+//   - StepBracket: Rate of Base, the income taxed from Start to Limit,
+//     which is zero for the open top bracket.
+//   - StepTotalTax: the sum of the bracket taxes.
+type Step struct {
+	Kind      StepKind
+	Month     int64   `json:",omitempty"`
+	LastMonth int64   `json:",omitempty"`
+	Count     float64 `json:",omitempty"`
+	Of        float64 `json:",omitempty"`
+	Base      float64 `json:",omitempty"`
+	Rate      float64 `json:",omitempty"`
+	Cap       float64 `json:",omitempty"`
+	Capped    bool    `json:",omitempty"`
+	Start     float64 `json:",omitempty"`
+	Limit     float64 `json:",omitempty"`
+	Amount    float64
+}
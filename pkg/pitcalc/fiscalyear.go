@@ -0,0 +1,286 @@
+package pitcalc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FiscalYear is a Myanmar fiscal (budget) year, the period income is assessed
+// over. It runs from April to March, except from October 2018 to September
+// 2021 when it ran from October to September; a six-month interim year
+// bridged each change. The zero value means an unspecified April–March year.
+type FiscalYear struct {
+	start time.Time // first day
+	end   time.Time // day after the last day
+}
+
+// fiscalEras lists the fiscal year calendars in force from each date.
+var fiscalEras = []struct {
+	from       time.Time  // first day the calendar applied
+	startMonth time.Month // month each year starts in
+	months     int        // length of each year
+}{
+	{time.Time{}, time.April, 12},
+	{date(2018, time.April, 1), time.April, 6},
+	{date(2018, time.October, 1), time.October, 12},
+	{date(2021, time.October, 1), time.October, 6},
+	{date(2022, time.April, 1), time.April, 12},
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// day drops the time of day and location from t.
+func day(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day())
+}
+
+// FiscalYearOf returns the fiscal year containing the given date.
+func FiscalYearOf(t time.Time) FiscalYear {
+	d := day(t)
+	era := fiscalEras[0]
+	for _, e := range fiscalEras[1:] {
+		if !d.Before(e.from) {
+			era = e
+		}
+	}
+	start := era.from
+	if era.months == 12 {
+		year := d.Year()
+		if d.Month() < era.startMonth {
+			year--
+		}
+		start = date(year, era.startMonth, 1)
+	}
+	return FiscalYear{start: start, end: start.AddDate(0, era.months, 0)}
+}
+
+// CurrentFiscalYear returns the fiscal year containing today.
+func CurrentFiscalYear() FiscalYear {
+	return FiscalYearOf(time.Now())
+}
+
+// ParseFiscalYear parses a fiscal year written as by Code or Label, such as
+// "2026-27", "FY 2026-2027" or "2026/27".
+func ParseFiscalYear(s string) (FiscalYear, error) {
+	code := strings.TrimSpace(strings.ToUpper(s))
+	code = strings.TrimSpace(strings.TrimPrefix(code, "INTERIM"))
+	code = strings.TrimSpace(strings.TrimPrefix(code, "FY"))
+	first, second, pair := strings.Cut(strings.ReplaceAll(code, "/", "-"), "-")
+	startYear, err := strconv.Atoi(first)
+	if err != nil || len(first) != 4 {
+		return FiscalYear{}, fmt.Errorf("invalid fiscal year %q", s)
+	}
+
+	// A year within one calendar year can only be an interim year, found
+	// from its middle; others are found from the end of the first calendar
+	// year they cover.
+	fy := FiscalYearOf(date(startYear, time.June, 30))
+	if pair {
+		endYear, err := strconv.Atoi(second)
+		if err != nil || (len(second) != 2 && len(second) != 4) {
+			return FiscalYear{}, fmt.Errorf("invalid fiscal year %q", s)
+		}
+		want := startYear + 1
+		if len(second) == 2 {
+			want %= 100
+		}
+		if endYear != want {
+			return FiscalYear{}, fmt.Errorf("invalid fiscal year %q", s)
+		}
+		fy = FiscalYearOf(date(startYear, time.December, 31))
+	}
+	spansTwo := fy.End().Year() != fy.Start().Year()
+	if fy.Start().Year() != startYear || spansTwo != pair {
+		return FiscalYear{}, fmt.Errorf("no fiscal year %q", s)
+	}
+	return fy, nil
+}
+
+// IsZero reports whether the fiscal year is unspecified.
+func (fy FiscalYear) IsZero() bool {
+	return fy.start.IsZero()
+}
+
+// Start returns the first day of the fiscal year.
+func (fy FiscalYear) Start() time.Time {
+	return fy.start
+}
+
+// End returns the last day of the fiscal year.
+func (fy FiscalYear) End() time.Time {
+	return fy.end.AddDate(0, 0, -1)
+}
+
+// Months returns the number of months in the fiscal year: 12, or 6 for an
+// interim year.
+func (fy FiscalYear) Months() int64 {
+	if fy.IsZero() {
+		return 12
+	}
+	return int64((fy.end.Year()-fy.start.Year())*12 + int(fy.end.Month()-fy.start.Month()))
+}
+
+// Interim reports whether the fiscal year is a six-month interim year.
+func (fy FiscalYear) Interim() bool {
+	return fy.Months() != 12
+}
+
+// Contains reports whether the given date falls within the fiscal year.
+func (fy FiscalYear) Contains(t time.Time) bool {
+	d := day(t)
+	return !d.Before(fy.start) && d.Before(fy.end)
+}
+
+// MonthIndex returns the position of a calendar month (1 = January) in the
+// fiscal year, from 0 for its first month, and whether the year includes
+// the month. An unspecified year is taken to run from April to March.
+func (fy FiscalYear) MonthIndex(month int64) (int64, bool) {
+	if fy.IsZero() {
+		return BudgetMonthIndex(month), month >= 1 && month <= 12
+	}
+	i := (month - int64(fy.start.Month()) + 12) % 12
+	return i, month >= 1 && month <= 12 && i < fy.Months()
+}
+
+// CalendarMonths lists the calendar months (1 = January) of the fiscal year
+// in order.
+func (fy FiscalYear) CalendarMonths() []int64 {
+	first := int64(time.April)
+	if !fy.IsZero() {
+		first = int64(fy.start.Month())
+	}
+	months := make([]int64, fy.Months())
+	for i := range months {
+		months[i] = (first+int64(i)-1)%12 + 1
+	}
+	return months
+}
+
+// Code identifies the fiscal year by the calendar years it spans, e.g.
+// "2026-27", or "2018" for the April–September 2018 interim year.
+func (fy FiscalYear) Code() string {
+	if fy.IsZero() {
+		return ""
+	}
+	first, last := fy.Start().Year(), fy.End().Year()
+	if first == last {
+		return strconv.Itoa(first)
+	}
+	return fmt.Sprintf("%d-%02d", first, last%100)
+}
+
+// Label names the fiscal year for reports, e.g. "FY 2026-27" or
+// "Interim FY 2021-22".
+func (fy FiscalYear) Label() string {
+	if fy.IsZero() {
+		return ""
+	}
+	if fy.Interim() {
+		return "Interim FY " + fy.Code()
+	}
+	return "FY " + fy.Code()
+}
+
+// String returns the fiscal year's label.
+func (fy FiscalYear) String() string {
+	return fy.Label()
+}
+
+// Previous returns the fiscal year before this one.
+func (fy FiscalYear) Previous() FiscalYear {
+	return FiscalYearOf(fy.start.AddDate(0, 0, -1))
+}
+
+// MarshalText encodes the fiscal year as its code, so saved calculations
+// record it as e.g. "2026-27".
+func (fy FiscalYear) MarshalText() ([]byte, error) {
+	return []byte(fy.Code()), nil
+}
+
+// UnmarshalText decodes a fiscal year written by MarshalText.
+func (fy *FiscalYear) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*fy = FiscalYear{}
+		return nil
+	}
+	parsed, err := ParseFiscalYear(string(text))
+	if err != nil {
+		return err
+	}
+	*fy = parsed
+	return nil
+}
+
+// Employment is the part of a fiscal year someone was employed, worked out
+// from the dates they joined and left.
+type Employment struct {
+	Year FiscalYear
+	// Joined and Left are the first and last days employed within the year.
+	Joined time.Time
+	Left   time.Time
+	// StartingMonth and EndingMonth are the calendar months (1 = January)
+	// of Joined and Left.
+	StartingMonth int64
+	EndingMonth   int64
+	// Months counts every month employed, including partial ones, in full;
+	// see Prorate for counting them in part.
+	Months int64
+}
+
+// Errors returned by Employment.
+var (
+	ErrNoFiscalYear = errors.New("fiscal year is required")
+	ErrNotEmployed  = errors.New("not employed during the fiscal year")
+)
+
+// Employment works out the months within the fiscal year between the dates
+// someone joined and left, inclusive. A zero date, or one outside the year,
+// stands for the year's first or last day.
+func (fy FiscalYear) Employment(joined, left time.Time) (Employment, error) {
+	if fy.IsZero() {
+		return Employment{}, ErrNoFiscalYear
+	}
+	first, last := fy.Start(), fy.End()
+	if !joined.IsZero() && day(joined).After(first) {
+		first = day(joined)
+	}
+	if !left.IsZero() && day(left).Before(last) {
+		last = day(left)
+	}
+	if last.Before(first) {
+		return Employment{}, fmt.Errorf("%w: %s", ErrNotEmployed, fy.Label())
+	}
+
+	e := Employment{
+		Year:          fy,
+		Joined:        first,
+		Left:          last,
+		StartingMonth: int64(first.Month()),
+		EndingMonth:   int64(last.Month()),
+	}
+	for m := date(first.Year(), first.Month(), 1); !m.After(last); m = m.AddDate(0, 1, 0) {
+		e.Months++
+	}
+	return e, nil
+}
+
+// daysBetween counts the days from one date to another, inclusive.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24) + 1
+}
+
+// Apply sets the fiscal year, the dates joined and left, and the starting
+// and ending months of a calculation input from the employment.
+func (e Employment) Apply(in CalculatePITInput) CalculatePITInput {
+	in.FiscalYear = e.Year
+	in.Joined = e.Joined
+	in.Left = e.Left
+	in.StartingMonth = e.StartingMonth
+	in.EndingMonth = e.EndingMonth
+	return in
+}
@@ -0,0 +1,227 @@
+package pitcalc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HouseholdInput is a couple who both earn, with the dependents they
+// support between them. Each spouse's input says how many of the parents
+// and children that spouse claims, or lists them as Dependents.
+type HouseholdInput struct {
+	Spouses [2]CalculatePITInput
+	// DependentParents and Childrens are the household's dependents, from
+	// either spouse's family; each may be claimed by only one spouse.
+	DependentParents int64
+	Childrens        int64
+}
+
+// HouseholdOutput holds each spouse's tax and the household's totals. A
+// spouse who lists their dependents has the decision on each in their
+// output's Dependents.
+type HouseholdOutput struct {
+	Spouses     [2]*CalculatePITOutput
+	GrossIncome float64
+	TotalTax    float64
+}
+
+// Errors returned by CalculateHousehold and BestClaims, some wrapped with the
+// spouse or numbers at fault.
+var (
+	ErrHouseholdNegative = errors.New("number of household dependents cannot be negative")
+	ErrHouseholdParents  = fmt.Errorf("the spouses cannot claim more than %d parents between them", 2*MaxDependentParents)
+	ErrHouseholdClaims   = errors.New("the spouses claim more dependents than the household has")
+	ErrEarningSpouse     = errors.New("a spouse who earns cannot be claimed as a dependent spouse")
+)
+
+// A ClaimError is a dependent claimed twice: listed twice by one spouse, or
+// claimed by both.
+type ClaimError struct {
+	// Name is the dependent's name as the first spouse to claim them gave it.
+	Name string
+	// Spouse is the spouse, 1 or 2, who lists the dependent twice, or 0 when
+	// both spouses claim them.
+	Spouse int
+}
+
+func (e *ClaimError) Error() string {
+	if e.Spouse != 0 {
+		return fmt.Sprintf("spouse %d: %s is listed twice", e.Spouse, e.Name)
+	}
+	return fmt.Sprintf("%s is claimed by both spouses", e.Name)
+}
+
+// dependentKey identifies a dependent listed by either spouse by their
+// relationship and name, ignoring case and spacing. Dependents without a
+// name cannot be told apart and have no key.
+func dependentKey(d Dependent) string {
+	name := strings.ToLower(strings.Join(strings.Fields(d.Name), " "))
+	if name == "" {
+		return ""
+	}
+	return string(d.Relationship) + ":" + name
+}
+
+// claimable reports whether a parent or child was accepted, or would have
+// been but for the limit on parents one taxpayer may claim, so that the
+// other spouse may claim them.
+func claimable(d DependentDecision) bool {
+	switch d.Dependent.Relationship {
+	case RelationParent, RelationChild:
+		return d.Accepted || d.Reason == ReasonParentLimit
+	}
+	return false
+}
+
+// listedDependents returns the parents and children either spouse may
+// claim from the dependents they list, a dependent listed by both only
+// once, and the other dependents listed by each spouse.
+func (in HouseholdInput) listedDependents() (parents, children []Dependent, others [2][]Dependent) {
+	seen := map[string]bool{}
+	for i, s := range in.Spouses {
+		_, decisions := s.ClaimDependents()
+		for _, d := range decisions {
+			if !claimable(d) {
+				others[i] = append(others[i], d.Dependent)
+				continue
+			}
+			if key := dependentKey(d.Dependent); key != "" {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			if d.Dependent.Relationship == RelationParent {
+				parents = append(parents, d.Dependent)
+			} else {
+				children = append(children, d.Dependent)
+			}
+		}
+	}
+	return parents, children, others
+}
+
+// ListedDependents counts the parents and children the spouses list as
+// Dependents, counting a dependent listed by both, by the same name and
+// relationship, once. It reports false when a spouse claims parents or
+// children by number only, as the household's dependents cannot then be
+// told from the claims.
+func (in HouseholdInput) ListedDependents() (parents, children int64, ok bool) {
+	for _, s := range in.Spouses {
+		if len(s.Dependents) == 0 && (s.DependentParents != 0 || s.Childrens != 0) {
+			return 0, 0, false
+		}
+	}
+	p, c, _ := in.listedDependents()
+	return int64(len(p)), int64(len(c)), true
+}
+
+// CalculateHousehold computes each spouse's tax, checking that no dependent
+// is claimed by both and that neither claims the other, who earns, as a
+// dependent spouse. A spouse who lists their dependents claims those found
+// eligible. It fails when a dependent is claimed twice: accepted for both
+// spouses, or listed twice by one, by the same name and relationship.
+func CalculateHousehold(in HouseholdInput) (*HouseholdOutput, error) {
+	if in.DependentParents < 0 || in.Childrens < 0 {
+		return nil, ErrHouseholdNegative
+	}
+	var parents, children int64
+	// claimedBy holds the spouse who claims each dependent, and the name
+	// they gave.
+	type claim struct {
+		spouse int
+		name   string
+	}
+	claimedBy := map[string]claim{}
+	for i, s := range in.Spouses {
+		s, decisions := s.ClaimDependents()
+		for _, d := range decisions {
+			key := dependentKey(d.Dependent)
+			if !d.Accepted || key == "" || d.Dependent.Relationship == RelationSpouse {
+				continue
+			}
+			if c, ok := claimedBy[key]; ok {
+				if c.spouse == i {
+					return nil, &ClaimError{Name: c.name, Spouse: i + 1}
+				}
+				return nil, &ClaimError{Name: c.name}
+			}
+			claimedBy[key] = claim{i, d.Dependent.Name}
+		}
+		if s.DependentSpouse != 0 {
+			return nil, fmt.Errorf("spouse %d: %w", i+1, ErrEarningSpouse)
+		}
+		parents += s.DependentParents
+		children += s.Childrens
+	}
+	if parents > in.DependentParents {
+		return nil, fmt.Errorf("%w: %d parents claimed, %d in the household", ErrHouseholdClaims, parents, in.DependentParents)
+	}
+	if children > in.Childrens {
+		return nil, fmt.Errorf("%w: %d children claimed, %d in the household", ErrHouseholdClaims, children, in.Childrens)
+	}
+
+	out := &HouseholdOutput{}
+	for i, s := range in.Spouses {
+		result, err := CalculatePIT(s)
+		if err != nil {
+			return nil, fmt.Errorf("spouse %d: %w", i+1, err)
+		}
+		out.Spouses[i] = result
+		out.GrossIncome += result.GrossIncome
+		out.TotalTax += result.TotalTax
+	}
+	return out, nil
+}
+
+// BestClaims returns the household with all its dependents claimed between
+// the spouses in the way that pays the least tax, using Advise. When the
+// claims as entered already cover every dependent, they are kept unless
+// another way pays less. When the spouses list the household's dependents,
+// they are handed to the spouses as the best claims share them, so each
+// spouse keeps a decision on every dependent they claim.
+func (in HouseholdInput) BestClaims() (HouseholdInput, error) {
+	if in.DependentParents < 0 || in.Childrens < 0 {
+		return in, ErrHouseholdNegative
+	}
+	if in.DependentParents > 2*MaxDependentParents {
+		return in, ErrHouseholdParents
+	}
+	listed := in
+	// Start from every dependent claimed, as far as possible by the first
+	// spouse, unless the claims already cover them all.
+	var parents, children int64
+	for i := range in.Spouses {
+		in.Spouses[i], _ = in.Spouses[i].ClaimDependents()
+	}
+	for _, s := range in.Spouses {
+		parents += s.DependentParents
+		children += s.Childrens
+	}
+	if parents != in.DependentParents || children != in.Childrens {
+		first := min(in.DependentParents, MaxDependentParents)
+		in.Spouses[0].DependentParents, in.Spouses[1].DependentParents = first, in.DependentParents-first
+		in.Spouses[0].Childrens, in.Spouses[1].Childrens = in.Childrens, 0
+	}
+
+	a, err := Advise(in.Spouses[0], in.Spouses[1])
+	if err != nil {
+		return in, err
+	}
+	copy(in.Spouses[:], a.Allocations[0].Inputs)
+
+	p, c, others := listed.listedDependents()
+	if _, _, ok := listed.ListedDependents(); !ok || int64(len(p)) != in.DependentParents || int64(len(c)) != in.Childrens {
+		return in, nil
+	}
+	for i := range in.Spouses {
+		claims := in.Spouses[i]
+		var deps []Dependent
+		deps = append(deps, p[:claims.DependentParents]...)
+		deps = append(deps, c[:claims.Childrens]...)
+		p, c = p[claims.DependentParents:], c[claims.Childrens:]
+		in.Spouses[i].Dependents = append(deps, others[i]...)
+	}
+	return in, nil
+}
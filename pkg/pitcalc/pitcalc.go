@@ -1,8 +1,29 @@
 package pitcalc
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"time"
+)
+
+// RuleSetVersion identifies the brackets and relief amounts below. It is
+// stored with saved calculations so results made under older rules can be
+// told apart.
+const RuleSetVersion = "mm-pit-2024.1"
+
+// Relief amounts and limits applied by CalculatePIT.
+const (
+	BasicReliefRate    = 0.2
+	BasicReliefCap     = 10000000.0
+	ParentReliefAmount = 1000000.0
+	SpouseReliefAmount = 1000000.0
+	ChildReliefAmount  = 500000.0
+	// MaxDependentParents is the most parents one taxpayer may claim.
+	MaxDependentParents = 2
+	// SSBMaxMonthly is the largest SSB contribution an employee makes in a
+	// month: 2% of the 300,000 kyat salary ceiling.
+	SSBMaxMonthly = 6000.0
 )
 
 // TaxBracket represents a tax bracket with an upper limit and a tax rate.
@@ -13,18 +34,100 @@ type TaxBracket struct {
 }
 
 // CalculatePITInput holds the input parameters for calculating personal income
-// tax.
+// tax. EndingMonth is the last month of employment for staff leaving during the
+// year; zero means the end of the fiscal year. FiscalYear is the year
+// assessed; when it is not set, months are counted in an April–March year.
+//
+// Joined and Left, when either is set, are the dates employment began and
+// ended; the starting and ending months are then taken from them, within
+// FiscalYear or else the year of the first date. Proration sets how a month
+// worked only in part is counted; by default it counts in full.
+//
+// Dependents, when set, lists the people the taxpayer supports; the
+// dependent counts are then taken from those found eligible, as by
+// ClaimDependents.
 type CalculatePITInput struct {
 	MonthlyIncome    float64
 	StartingMonth    int64
+	EndingMonth      int64
 	DependentParents int64
 	DependentSpouse  int64
 	Childrens        int64
 	SSB              float64
+	FiscalYear       FiscalYear  `json:",omitzero"`
+	Joined           time.Time   `json:",omitzero"`
+	Left             time.Time   `json:",omitzero"`
+	Proration        Proration   `json:",omitempty"`
+	Dependents       []Dependent `json:",omitempty"`
+}
+
+// datedYear returns the fiscal year the input's dates fall in: FiscalYear,
+// or else the year of Joined or of Left. It is zero when none is set.
+func (input CalculatePITInput) datedYear() FiscalYear {
+	switch {
+	case !input.FiscalYear.IsZero():
+		return input.FiscalYear
+	case !input.Joined.IsZero():
+		return FiscalYearOf(input.Joined)
+	case !input.Left.IsZero():
+		return FiscalYearOf(input.Left)
+	}
+	return FiscalYear{}
+}
+
+// Normalize returns the input as CalculatePIT counts it: with the dependent
+// counts taken from Dependents, as by ClaimDependents, and the fiscal year
+// and months taken from Joined and Left. Reports show it so their figures
+// match the tax worked out.
+func (input CalculatePITInput) Normalize() (CalculatePITInput, error) {
+	input, _, _, err := input.normalize()
+	return input, err
+}
+
+// normalize returns the normalized input with the decision on each
+// dependent and the months counted in part.
+func (input CalculatePITInput) normalize() (CalculatePITInput, []DependentDecision, []ProratedMonth, error) {
+	input, dependents := input.ClaimDependents()
+	if input.Joined.IsZero() && input.Left.IsZero() {
+		return input, dependents, nil, nil
+	}
+	e, err := input.datedYear().Employment(input.Joined, input.Left)
+	if err != nil {
+		return input, nil, nil, err
+	}
+	input = e.Apply(input)
+	return input, dependents, e.Prorate(input.Proration), nil
+}
+
+// BudgetMonthIndex returns the position of a calendar month (1 = January)
+// in the April–March budget year, from 0 for April to 11 for March.
+func BudgetMonthIndex(month int64) int64 {
+	return (month + 8) % 12
+}
+
+// LastMonth returns the last month counted: EndingMonth, or the last month
+// of the fiscal year (March unless it is set) when it is not set.
+func (input CalculatePITInput) LastMonth() int64 {
+	if input.EndingMonth == 0 {
+		months := input.FiscalYear.CalendarMonths()
+		return months[len(months)-1]
+	}
+	return input.EndingMonth
+}
+
+// Months returns the number of months from StartingMonth to LastMonth within
+// the fiscal year.
+func (input CalculatePITInput) Months() int64 {
+	first, _ := input.FiscalYear.MonthIndex(input.StartingMonth)
+	last, _ := input.FiscalYear.MonthIndex(input.LastMonth())
+	return last - first + 1
 }
 
 // CalculatePITOutput holds the output results from calculating personal income
-// tax.
+// tax. Proration lists the months counted in part, whose income is included
+// in GrossIncome. Dependents holds the decision on each dependent listed in
+// the input. Explanation lists the steps of the calculation, in order, so the
+// figures can be checked by hand.
 type CalculatePITOutput struct {
 	TaxBreakdown []struct {
 		Start  float64
@@ -38,69 +141,129 @@ type CalculatePITOutput struct {
 	SpouseRelief float64
 	ChildRelief  float64
 	SSBRelief    float64
+	Proration    []ProratedMonth     `json:",omitempty"`
+	Dependents   []DependentDecision `json:",omitempty"`
+	Explanation  []Step              `json:",omitempty"`
 
 	TotalRelief  float64
 	TotalTexable float64
 	TotalTax     float64
 }
 
+// Errors returned by CalculatePIT for input it does not accept, some wrapped
+// with the value or fiscal year at fault. Front-ends test for them with
+// errors.Is to describe the problem in the user's language.
+var (
+	ErrIncome           = errors.New("monthly income must be greater than 0")
+	ErrProration        = errors.New("unknown proration")
+	ErrStartingMonth    = errors.New("starting month must be between 1 and 12")
+	ErrEndingMonth      = errors.New("ending month must be between 1 and 12")
+	ErrMonthNotInYear   = errors.New("month is not in the fiscal year")
+	ErrEndBeforeStart   = errors.New("ending month cannot be before starting month")
+	ErrNegativeParents  = errors.New("number of dependent parents cannot be negative")
+	ErrTooManyParents   = fmt.Errorf("number of dependent parents cannot exceed %d", MaxDependentParents)
+	ErrSpouse           = errors.New("dependent spouse value must be 0 or 1")
+	ErrNegativeChildren = errors.New("number of children cannot be negative")
+	ErrNegativeSSB      = errors.New("yearly SSB contribution cannot be negative")
+)
+
 // CalculatePIT computes personal income tax for Myanmar.
 func CalculatePIT(input CalculatePITInput) (*CalculatePITOutput, error) {
 
 	// Validate input
 	if input.MonthlyIncome <= 0 {
 
-		return nil, fmt.Errorf("monthly income must be greater than 0")
+		return nil, ErrIncome
+	}
+	switch input.Proration {
+	case ProrateNone, ProrateCalendarDays, ProrateWorkingDays:
+	default:
+		return nil, fmt.Errorf("%w %q", ErrProration, input.Proration)
+	}
+	input, dependents, prorated, err := input.normalize()
+	if err != nil {
+		return nil, err
 	}
 	if input.StartingMonth < 1 || input.StartingMonth > 12 {
-		return nil, fmt.Errorf("starting month must be between 1 and 12")
+		return nil, ErrStartingMonth
+	}
+	if input.EndingMonth < 0 || input.EndingMonth > 12 {
+		return nil, ErrEndingMonth
+	}
+	year := "the April-March year"
+	if !input.FiscalYear.IsZero() {
+		year = input.FiscalYear.Label()
+	}
+	first, ok := input.FiscalYear.MonthIndex(input.StartingMonth)
+	if !ok {
+		return nil, fmt.Errorf("starting %w: %s", ErrMonthNotInYear, year)
+	}
+	last, ok := input.FiscalYear.MonthIndex(input.LastMonth())
+	if !ok {
+		return nil, fmt.Errorf("ending %w: %s", ErrMonthNotInYear, year)
+	}
+	if last < first {
+		return nil, fmt.Errorf("%w in %s", ErrEndBeforeStart, year)
 	}
 	if input.DependentParents < 0 {
-		return nil, fmt.Errorf("number of dependent parents cannot be negative")
+		return nil, ErrNegativeParents
 	}
-	if input.DependentParents > 2 {
-		return nil, fmt.Errorf("number of dependent parents cannot exceed 2")
+	if input.DependentParents > MaxDependentParents {
+		return nil, ErrTooManyParents
 	}
 	if input.DependentSpouse < 0 || input.DependentSpouse > 1 {
-		return nil, fmt.Errorf("dependent spouse value must be 0 or 1")
+		return nil, ErrSpouse
 	}
 	if input.Childrens < 0 {
-		return nil, fmt.Errorf("number of children cannot be negative")
+		return nil, ErrNegativeChildren
 	}
 	if input.SSB < 0 {
-		return nil, fmt.Errorf("yearly SSB contribution cannot be negative")
+		return nil, ErrNegativeSSB
 	}
 
-	// Determine months in the budget year (April=4, ..., March=3)
-	var months int64
-	if input.StartingMonth >= 4 {
-
-		// Apr(4)->12, Dec(12)->4
-		months = 16 - input.StartingMonth
-	} else {
-
-		// Jan(1)->3, Mar(3)->1
-		months = 4 - input.StartingMonth
-	}
+	months := input.Months()
+	steps := []Step{{Kind: StepMonths, Month: input.StartingMonth, LastMonth: input.LastMonth(), Count: float64(months), Amount: float64(months)}}
 
 	yearlyGrossIncome := input.MonthlyIncome * float64(months)
+	for i := range prorated {
+		prorated[i].Income = input.MonthlyIncome * prorated[i].Fraction()
+		yearlyGrossIncome -= input.MonthlyIncome - prorated[i].Income
+		steps = append(steps, Step{Kind: StepProration, Month: prorated[i].Month, Base: input.MonthlyIncome,
+			Count: float64(prorated[i].Days), Of: float64(prorated[i].DaysInMonth), Amount: prorated[i].Income})
+	}
+	steps = append(steps, Step{Kind: StepGross, Base: input.MonthlyIncome,
+		Count: float64(months - int64(len(prorated))), Amount: yearlyGrossIncome})
 
 	// Reliefs
-	personalRelief := 0.2 * float64(yearlyGrossIncome)
-	if personalRelief > 10000000 {
+	personalRelief := BasicReliefRate * float64(yearlyGrossIncome)
+	basic := Step{Kind: StepBasicRelief, Base: yearlyGrossIncome, Rate: BasicReliefRate, Cap: BasicReliefCap}
+	if personalRelief > BasicReliefCap {
 
-		personalRelief = 10000000
+		personalRelief = BasicReliefCap
+		basic.Capped = true
 	}
-	parentRelief := float64(input.DependentParents) * 1000000
-	spouseRelief := float64(input.DependentSpouse) * 1000000
-	childRelief := float64(input.Childrens) * 500000.0
+	basic.Amount = personalRelief
+	parentRelief := float64(input.DependentParents) * ParentReliefAmount
+	spouseRelief := float64(input.DependentSpouse) * SpouseReliefAmount
+	childRelief := float64(input.Childrens) * ChildReliefAmount
 	totalRelief := personalRelief + parentRelief + spouseRelief + childRelief + input.SSB
+	steps = append(steps, basic,
+		Step{Kind: StepParentRelief, Count: float64(input.DependentParents), Base: ParentReliefAmount, Cap: MaxDependentParents, Amount: parentRelief},
+		Step{Kind: StepSpouseRelief, Count: float64(input.DependentSpouse), Base: SpouseReliefAmount, Cap: 1, Amount: spouseRelief},
+		Step{Kind: StepChildRelief, Count: float64(input.Childrens), Base: ChildReliefAmount, Amount: childRelief},
+		Step{Kind: StepSSBRelief, Base: input.SSB, Amount: input.SSB},
+		Step{Kind: StepTotalRelief, Amount: totalRelief},
+	)
 
 	taxableIncome := yearlyGrossIncome - totalRelief
+	taxable := Step{Kind: StepTaxable, Base: yearlyGrossIncome, Of: totalRelief}
 	if taxableIncome < 0 {
 
 		taxableIncome = 0
+		taxable.Capped = true
 	}
+	taxable.Amount = taxableIncome
+	steps = append(steps, taxable)
 
 	output := CalculatePITOutput{
 		GrossIncome:  yearlyGrossIncome,
@@ -109,6 +272,8 @@ func CalculatePIT(input CalculatePITInput) (*CalculatePITOutput, error) {
 		SpouseRelief: spouseRelief,
 		ChildRelief:  childRelief,
 		SSBRelief:    input.SSB,
+		Proration:    prorated,
+		Dependents:   dependents,
 		TotalRelief:  totalRelief,
 		TotalTexable: taxableIncome,
 	}
@@ -146,14 +311,28 @@ func CalculatePIT(input CalculatePITInput) (*CalculatePITOutput, error) {
 			Rate:   bracket.Rate,
 			Amount: tax,
 		})
+		limit := bracket.Limit
+		if math.IsInf(limit, 1) {
+			limit = 0
+		}
+		steps = append(steps, Step{Kind: StepBracket, Start: bracket.Start, Limit: limit, Base: part, Rate: bracket.Rate, Amount: tax})
 		output.TotalTax += tax
 		remaining -= part
 		previousLimit = bracket.Limit
 	}
+	output.Explanation = append(steps, Step{Kind: StepTotalTax, Amount: output.TotalTax})
 
 	return &output, nil
 }
 
+// Brackets returns a copy of the progressive tax brackets, ordered from the
+// lowest to the highest.
+func Brackets() []TaxBracket {
+	out := make([]TaxBracket, len(brackets))
+	copy(out, brackets)
+	return out
+}
+
 var brackets = []TaxBracket{
 
 	{1, 2000000, 0.00},
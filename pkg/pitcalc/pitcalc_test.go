@@ -1,7 +1,12 @@
 package pitcalc
 
 import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCalculatePIT_InvalidMonthlyIncome(t *testing.T) {
@@ -468,3 +473,705 @@ func TestCalculatePIT_AllMonthsStartingMonths(t *testing.T) {
 		}
 	}
 }
+
+func TestBrackets_ReturnsCopy(t *testing.T) {
+	got := Brackets()
+	if len(got) != len(brackets) {
+		t.Fatalf("expected %d brackets, got %d", len(brackets), len(got))
+	}
+	got[0].Rate = 0.99
+	if brackets[0].Rate == 0.99 {
+		t.Errorf("modifying the returned slice changed the package brackets")
+	}
+}
+
+func TestCalculatePITInput_Months(t *testing.T) {
+	tests := []struct {
+		startingMonth int64
+		endingMonth   int64
+		expected      int64
+	}{
+		{4, 0, 12},
+		{12, 0, 4},
+		{1, 0, 3},
+		{3, 0, 1},
+		{4, 3, 12},
+		{4, 9, 6},
+		{7, 12, 6},
+		{11, 2, 4},
+		{1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		input := CalculatePITInput{StartingMonth: tt.startingMonth, EndingMonth: tt.endingMonth}
+		if got := input.Months(); got != tt.expected {
+			t.Errorf("months %d-%d: expected %d, got %d", tt.startingMonth, tt.endingMonth, tt.expected, got)
+		}
+	}
+}
+
+func TestCalculatePIT_EndingMonth(t *testing.T) {
+	result, err := CalculatePIT(CalculatePITInput{
+		MonthlyIncome: 500000,
+		StartingMonth: 4,
+		EndingMonth:   9,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.GrossIncome != 3000000 {
+		t.Errorf("expected gross income 3000000 for April to September, got %f", result.GrossIncome)
+	}
+
+	tests := []struct {
+		name          string
+		startingMonth int64
+		endingMonth   int64
+		expectedError string
+	}{
+		{"out of range", 4, 13, "ending month must be between 1 and 12"},
+		{"negative", 4, -1, "ending month must be between 1 and 12"},
+		{"before start", 10, 6, "ending month cannot be before starting month in the April-March year"},
+		{"across the year boundary", 1, 12, "ending month cannot be before starting month in the April-March year"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculatePIT(CalculatePITInput{
+				MonthlyIncome: 500000,
+				StartingMonth: tt.startingMonth,
+				EndingMonth:   tt.endingMonth,
+			})
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestFiscalYearOf(t *testing.T) {
+	tests := []struct {
+		date   time.Time
+		code   string
+		label  string
+		months int64
+		first  int64
+	}{
+		{date(2026, time.October, 19), "2026-27", "FY 2026-27", 12, 4},
+		{date(2027, time.March, 31), "2026-27", "FY 2026-27", 12, 4},
+		{date(2027, time.April, 1), "2027-28", "FY 2027-28", 12, 4},
+		{date(2017, time.May, 1), "2017-18", "FY 2017-18", 12, 4},
+		{date(2018, time.March, 31), "2017-18", "FY 2017-18", 12, 4},
+		{date(2018, time.April, 1), "2018", "Interim FY 2018", 6, 4},
+		{date(2018, time.September, 30), "2018", "Interim FY 2018", 6, 4},
+		{date(2018, time.October, 1), "2018-19", "FY 2018-19", 12, 10},
+		{date(2020, time.January, 15), "2019-20", "FY 2019-20", 12, 10},
+		{date(2021, time.September, 30), "2020-21", "FY 2020-21", 12, 10},
+		{date(2021, time.October, 1), "2021-22", "Interim FY 2021-22", 6, 10},
+		{date(2022, time.March, 31), "2021-22", "Interim FY 2021-22", 6, 10},
+		{date(2022, time.April, 1), "2022-23", "FY 2022-23", 12, 4},
+	}
+	for _, tt := range tests {
+		fy := FiscalYearOf(tt.date)
+		if fy.Code() != tt.code || fy.Label() != tt.label || fy.Months() != tt.months || fy.CalendarMonths()[0] != tt.first {
+			t.Errorf("%s: expected %s, %q, %d months from %d, got %s, %q, %d months from %d",
+				tt.date.Format("2006-01-02"), tt.code, tt.label, tt.months, tt.first,
+				fy.Code(), fy.Label(), fy.Months(), fy.CalendarMonths()[0])
+		}
+		if !fy.Contains(tt.date) || fy.Contains(fy.End().AddDate(0, 0, 1)) || fy.Contains(fy.Start().AddDate(0, 0, -1)) {
+			t.Errorf("%s: %s does not cover exactly its own days", tt.date.Format("2006-01-02"), fy)
+		}
+		if next := FiscalYearOf(fy.End().AddDate(0, 0, 1)); next.Previous() != fy {
+			t.Errorf("%s: expected the year before %s to be %s, got %s", tt.date.Format("2006-01-02"), next, fy, next.Previous())
+		}
+	}
+}
+
+func TestParseFiscalYear(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2026-27", "2026-27"},
+		{"FY 2026-27", "2026-27"},
+		{"fy2026/2027", "2026-27"},
+		{"2019-20", "2019-20"},
+		{"2018", "2018"},
+		{"Interim FY 2021-22", "2021-22"},
+		{"1999-00", "1999-00"},
+		{"2026", ""},
+		{"2026-28", ""},
+		{"2021-2022x", ""},
+		{"26-27", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		fy, err := ParseFiscalYear(tt.in)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseFiscalYear(%q): expected an error, got %s", tt.in, fy)
+			}
+			continue
+		}
+		if err != nil || fy.Code() != tt.want {
+			t.Errorf("ParseFiscalYear(%q): expected %s, got %s, %v", tt.in, tt.want, fy.Code(), err)
+		}
+	}
+}
+
+func TestFiscalYear_Employment(t *testing.T) {
+	fy := FiscalYearOf(date(2026, time.April, 1))
+	tests := []struct {
+		name           string
+		joined, left   time.Time
+		start, end     int64
+		months         int64
+		proratedMonths float64
+	}{
+		{"whole year", time.Time{}, time.Time{}, 4, 3, 12, 12},
+		{"joined mid-month", date(2026, time.June, 20), time.Time{}, 6, 3, 10, 9 + 11.0/30},
+		{"left mid-month", time.Time{}, date(2026, time.September, 15), 4, 9, 6, 5.5},
+		{"joined before the year", date(2025, time.August, 1), date(2026, time.May, 31), 4, 5, 2, 2},
+		{"within one month", date(2027, time.February, 1), date(2027, time.February, 14), 2, 2, 1, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := fy.Employment(tt.joined, tt.left)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e.StartingMonth != tt.start || e.EndingMonth != tt.end || e.Months != tt.months {
+				t.Errorf("expected months %d–%d (%d), got %d–%d (%d)", tt.start, tt.end, tt.months, e.StartingMonth, e.EndingMonth, e.Months)
+			}
+			if got := e.ProratedMonths(ProrateCalendarDays); math.Abs(got-tt.proratedMonths) > 1e-9 {
+				t.Errorf("expected %f prorated months, got %f", tt.proratedMonths, got)
+			}
+			if in := e.Apply(CalculatePITInput{}); in.Months() != tt.months || in.FiscalYear != fy {
+				t.Errorf("expected the input to count %d months in %s, got %d in %s", tt.months, fy, in.Months(), in.FiscalYear)
+			}
+		})
+	}
+
+	if _, err := fy.Employment(date(2027, time.April, 2), time.Time{}); err == nil {
+		t.Error("expected an error when joining after the year")
+	}
+	if _, err := fy.Employment(date(2026, time.June, 1), date(2026, time.May, 1)); err == nil {
+		t.Error("expected an error when leaving before joining")
+	}
+}
+
+func TestCalculatePIT_FiscalYear(t *testing.T) {
+	octSep, _ := ParseFiscalYear("2019-20")
+	interim, _ := ParseFiscalYear("2018")
+
+	result, err := CalculatePIT(CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 10, FiscalYear: octSep})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.GrossIncome != 6000000 {
+		t.Errorf("expected 12 months from October in FY 2019-20, got gross income %f", result.GrossIncome)
+	}
+	result, err = CalculatePIT(CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, FiscalYear: interim})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.GrossIncome != 3000000 {
+		t.Errorf("expected the 2018 interim year to end in September, got gross income %f", result.GrossIncome)
+	}
+
+	tests := []struct {
+		name          string
+		input         CalculatePITInput
+		expectedError string
+	}{
+		{"start outside interim year", CalculatePITInput{StartingMonth: 10, FiscalYear: interim}, "starting month is not in the fiscal year: Interim FY 2018"},
+		{"end outside interim year", CalculatePITInput{StartingMonth: 4, EndingMonth: 12, FiscalYear: interim}, "ending month is not in the fiscal year: Interim FY 2018"},
+		{"across the year boundary", CalculatePITInput{StartingMonth: 4, EndingMonth: 12, FiscalYear: octSep}, "ending month cannot be before starting month in FY 2019-20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.MonthlyIncome = 500000
+			if _, err := CalculatePIT(tt.input); err == nil || err.Error() != tt.expectedError {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCalculatePITInput_FiscalYearJSON(t *testing.T) {
+	fy, _ := ParseFiscalYear("2026-27")
+	data, err := json.Marshal(CalculatePITInput{MonthlyIncome: 1, StartingMonth: 4, FiscalYear: fy})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"FiscalYear":"2026-27"`) {
+		t.Errorf("expected the fiscal year code, got %s", data)
+	}
+	var in CalculatePITInput
+	if err := json.Unmarshal(data, &in); err != nil || in.FiscalYear != fy {
+		t.Errorf("expected %s back, got %s, %v", fy, in.FiscalYear, err)
+	}
+
+	data, err = json.Marshal(CalculatePITInput{MonthlyIncome: 1, StartingMonth: 4})
+	if err != nil || strings.Contains(string(data), "FiscalYear") {
+		t.Errorf("expected an unset fiscal year to be omitted, got %s, %v", data, err)
+	}
+}
+
+func TestEmployment_Prorate(t *testing.T) {
+	fy := FiscalYearOf(date(2026, time.April, 1))
+	tests := []struct {
+		name         string
+		joined, left time.Time
+		basis        Proration
+		expected     []ProratedMonth
+	}{
+		{"full months", date(2026, time.June, 1), date(2026, time.September, 30), ProrateCalendarDays, nil},
+		{"not prorated", date(2026, time.June, 20), time.Time{}, ProrateNone, nil},
+		{"calendar days", date(2026, time.June, 20), date(2026, time.September, 15), ProrateCalendarDays, []ProratedMonth{
+			{Month: 6, Days: 11, DaysInMonth: 30, Basis: ProrateCalendarDays},
+			{Month: 9, Days: 15, DaysInMonth: 30, Basis: ProrateCalendarDays},
+		}},
+		{"working days", date(2026, time.June, 20), date(2026, time.September, 15), ProrateWorkingDays, []ProratedMonth{
+			{Month: 6, Days: 7, DaysInMonth: 22, Basis: ProrateWorkingDays},
+			{Month: 9, Days: 11, DaysInMonth: 22, Basis: ProrateWorkingDays},
+		}},
+		{"within one month", date(2027, time.February, 1), date(2027, time.February, 14), ProrateCalendarDays, []ProratedMonth{
+			{Month: 2, Days: 14, DaysInMonth: 28, Basis: ProrateCalendarDays},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := fy.Employment(tt.joined, tt.left)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := e.Prorate(tt.basis)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("expected %+v, got %+v", tt.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCalculatePIT_Proration(t *testing.T) {
+	joined := date(2026, time.June, 20)
+	tests := []struct {
+		name      string
+		proration Proration
+		gross     float64
+		income    float64
+	}{
+		{"full month", ProrateNone, 30000000, 0},
+		{"calendar days", ProrateCalendarDays, 3000000 * (9 + 11.0/30), 1100000},
+		{"working days", ProrateWorkingDays, 3000000 * (9 + 7.0/22), 3000000 * 7.0 / 22},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculatePIT(CalculatePITInput{MonthlyIncome: 3000000, Joined: joined, Proration: tt.proration})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(result.GrossIncome-tt.gross) > 1e-6 {
+				t.Errorf("expected gross income %f, got %f", tt.gross, result.GrossIncome)
+			}
+			if tt.income == 0 {
+				if len(result.Proration) != 0 {
+					t.Errorf("expected no prorated months, got %v", result.Proration)
+				}
+				return
+			}
+			if len(result.Proration) != 1 || result.Proration[0].Month != 6 || math.Abs(result.Proration[0].Income-tt.income) > 1e-6 {
+				t.Errorf("expected June prorated to %f, got %+v", tt.income, result.Proration)
+			}
+		})
+	}
+
+	errTests := []struct {
+		name          string
+		input         CalculatePITInput
+		expectedError string
+	}{
+		{"unknown proration", CalculatePITInput{StartingMonth: 4, Proration: "hourly"}, `unknown proration "hourly"`},
+		{"dates outside the year", CalculatePITInput{FiscalYear: FiscalYearOf(joined), Joined: date(2027, time.May, 1)}, "not employed during the fiscal year: FY 2026-27"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.MonthlyIncome = 3000000
+			if _, err := CalculatePIT(tt.input); err == nil || err.Error() != tt.expectedError {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestParseProration(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Proration
+		ok       bool
+	}{
+		{"", ProrateNone, true},
+		{"none", ProrateNone, true},
+		{"Calendar", ProrateCalendarDays, true},
+		{" working ", ProrateWorkingDays, true},
+		{"hourly", "", false},
+	}
+	for _, tt := range tests {
+		got, err := ParseProration(tt.in)
+		if (err == nil) != tt.ok || got != tt.expected {
+			t.Errorf("ParseProration(%q): expected %q, got %q, %v", tt.in, tt.expected, got, err)
+		}
+	}
+}
+
+func TestAdvise(t *testing.T) {
+	a, err := Advise(CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a.Allocations) != 1 || !a.Allocations[0].Entered || a.Allocations[0].Saving != 0 {
+		t.Errorf("expected only the claims as entered for one taxpayer, got %+v", a.Allocations)
+	}
+	expected := Contribution{SSBRoom: 72000, SSBSaving: 3600, LowerBracket: 7600000, LowerRate: 0, BracketSaving: 380000}
+	if len(a.Contributions) != 1 || a.Contributions[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, a.Contributions)
+	}
+
+	// The higher earner pays 10% on their top slice against 5% for the
+	// other, so the relief is worth more to them.
+	high := CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000}
+	low := CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, DependentParents: 2, Childrens: 1}
+	a, err = Advise(high, low)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.CurrentTax != 2287800 {
+		t.Errorf("expected tax as entered of 2287800, got %f", a.CurrentTax)
+	}
+	best := a.Allocations[0]
+	if best.Inputs[0].DependentParents != 2 || best.Inputs[0].Childrens != 1 || best.Inputs[1].DependentParents != 0 || best.Inputs[1].Childrens != 0 {
+		t.Errorf("expected the higher earner to claim every dependent, got %+v", best.Inputs)
+	}
+	if best.TotalTax != 2162800 || best.Saving != 125000 {
+		t.Errorf("expected total tax 2162800 saving 125000, got %f saving %f", best.TotalTax, best.Saving)
+	}
+	if len(a.Allocations) != 6 {
+		t.Errorf("expected 6 ways to share 2 parents and 1 child, got %d", len(a.Allocations))
+	}
+	if a.Contributions[0].SSBRoom != 0 || a.Contributions[1].SSBRoom != 72000 {
+		t.Errorf("expected SSB room only for the second taxpayer, got %+v", a.Contributions)
+	}
+
+	shared := CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4, DependentParents: 2}
+	a, err = Advise(shared, shared)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, alloc := range a.Allocations {
+		if alloc.Inputs[0].DependentParents > MaxDependentParents || alloc.Inputs[1].DependentParents > MaxDependentParents {
+			t.Errorf("expected at most %d parents each, got %+v", MaxDependentParents, alloc.Inputs)
+		}
+	}
+
+	errTests := []struct {
+		name          string
+		taxpayers     []CalculatePITInput
+		expectedError string
+	}{
+		{"no taxpayers", nil, "advice needs one or two taxpayers, got 0"},
+		{"three taxpayers", []CalculatePITInput{shared, shared, shared}, "advice needs one or two taxpayers, got 3"},
+		{"invalid input", []CalculatePITInput{shared, {StartingMonth: 4}}, "taxpayer 2: monthly income must be greater than 0"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Advise(tt.taxpayers...); err == nil || err.Error() != tt.expectedError {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCalculateHousehold(t *testing.T) {
+	high := CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000}
+	low := CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, DependentParents: 2, Childrens: 1}
+	in := HouseholdInput{Spouses: [2]CalculatePITInput{high, low}, DependentParents: 2, Childrens: 1}
+
+	out, err := CalculateHousehold(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Spouses[0].TotalTax != 2272800 || out.Spouses[1].TotalTax != 15000 || out.TotalTax != 2287800 {
+		t.Errorf("expected 2272800 + 15000 = 2287800, got %f + %f = %f", out.Spouses[0].TotalTax, out.Spouses[1].TotalTax, out.TotalTax)
+	}
+	if out.GrossIncome != 42000000 {
+		t.Errorf("expected household gross income 42000000, got %f", out.GrossIncome)
+	}
+
+	best, err := in.BestClaims()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if best.Spouses[0].DependentParents != 2 || best.Spouses[0].Childrens != 1 || best.Spouses[1].DependentParents != 0 {
+		t.Errorf("expected the higher earner to claim every dependent, got %+v", best.Spouses)
+	}
+	if out, err := CalculateHousehold(best); err != nil || out.TotalTax != 2162800 {
+		t.Errorf("expected household tax 2162800, got %v, %v", out, err)
+	}
+
+	// Both spouses claiming the same two parents is put right by BestClaims.
+	both := HouseholdInput{Spouses: [2]CalculatePITInput{low, low}, DependentParents: 2, Childrens: 1}
+	both.Spouses[0].MonthlyIncome = 3000000
+	best, err = both.BestClaims()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if best.Spouses[0].DependentParents+best.Spouses[1].DependentParents != 2 || best.Spouses[0].Childrens+best.Spouses[1].Childrens != 1 {
+		t.Errorf("expected each dependent claimed once, got %+v", best.Spouses)
+	}
+
+	errTests := []struct {
+		name          string
+		input         HouseholdInput
+		expectedError string
+	}{
+		{"parents claimed twice", both, "the spouses claim more dependents than the household has: 4 parents claimed, 2 in the household"},
+		{"children claimed twice", HouseholdInput{Spouses: [2]CalculatePITInput{low, low}, DependentParents: 4, Childrens: 1}, "the spouses claim more dependents than the household has: 2 children claimed, 1 in the household"},
+		{"dependent spouse", HouseholdInput{Spouses: [2]CalculatePITInput{high, {MonthlyIncome: 1, StartingMonth: 4, DependentSpouse: 1}}}, "spouse 2: a spouse who earns cannot be claimed as a dependent spouse"},
+		{"negative dependents", HouseholdInput{Spouses: [2]CalculatePITInput{high, high}, Childrens: -1}, "number of household dependents cannot be negative"},
+		{"invalid spouse", HouseholdInput{Spouses: [2]CalculatePITInput{high, {StartingMonth: 4}}}, "spouse 2: monthly income must be greater than 0"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CalculateHousehold(tt.input); err == nil || err.Error() != tt.expectedError {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+	if _, err := (HouseholdInput{Spouses: [2]CalculatePITInput{high, low}, DependentParents: 5}).BestClaims(); err == nil {
+		t.Error("expected an error for more parents than both spouses may claim")
+	}
+}
+
+func TestCalculateHousehold_Dependents(t *testing.T) {
+	mother := Dependent{Name: "Daw Mya", Relationship: RelationParent, LivesWith: true}
+	father := Dependent{Name: "U Ba", Relationship: RelationParent, LivesWith: true}
+	child := Dependent{Name: "Ko Ko", Relationship: RelationChild, BirthDate: time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC)}
+	apart := Dependent{Name: "U Tin", Relationship: RelationParent}
+	fy, _ := ParseFiscalYear("2026-27")
+	high := CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000, FiscalYear: fy}
+	low := CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, FiscalYear: fy}
+
+	// Both spouses list the same parents; the household has two, not four.
+	both := HouseholdInput{Spouses: [2]CalculatePITInput{high, low}}
+	both.Spouses[0].Dependents = []Dependent{mother, father}
+	both.Spouses[1].Dependents = []Dependent{{Name: " daw  MYA ", Relationship: RelationParent, LivesWith: true}, father, child, apart}
+	parents, children, ok := both.ListedDependents()
+	if !ok || parents != 2 || children != 1 {
+		t.Errorf("expected 2 parents and 1 child listed, got %d, %d, %t", parents, children, ok)
+	}
+	both.DependentParents, both.Childrens = parents, children
+	if _, err := CalculateHousehold(both); err == nil || err.Error() != "Daw Mya is claimed by both spouses" {
+		t.Errorf("expected error %q, got %v", "Daw Mya is claimed by both spouses", err)
+	}
+	twice := HouseholdInput{Spouses: [2]CalculatePITInput{high, low}, DependentParents: 2}
+	twice.Spouses[0].Dependents = []Dependent{mother, mother}
+	if _, err := CalculateHousehold(twice); err == nil || err.Error() != "spouse 1: Daw Mya is listed twice" {
+		t.Errorf("expected error %q, got %v", "spouse 1: Daw Mya is listed twice", err)
+	}
+
+	// BestClaims hands each dependent to one spouse and keeps the decisions.
+	best, err := both.BestClaims()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := CalculateHousehold(best)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.TotalTax != 2162800 {
+		t.Errorf("expected household tax 2162800, got %f", out.TotalTax)
+	}
+	var names []string
+	for _, d := range out.Spouses[0].Dependents {
+		if d.Accepted {
+			names = append(names, d.Dependent.Name)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"Daw Mya", "U Ba", "Ko Ko"}) {
+		t.Errorf("expected the higher earner to claim every dependent, got %v", names)
+	}
+	if d := out.Spouses[1].Dependents; len(d) != 1 || d[0].Dependent.Name != "U Tin" || d[0].Reason != ReasonParentApart {
+		t.Errorf("expected the parent living apart to stay rejected, got %+v", d)
+	}
+
+	counted := HouseholdInput{Spouses: [2]CalculatePITInput{high, low}}
+	counted.Spouses[1].DependentParents = 1
+	if _, _, ok := counted.ListedDependents(); ok {
+		t.Error("expected dependents claimed by number not to be listed")
+	}
+}
+
+func TestClaimDependents(t *testing.T) {
+	fy, _ := ParseFiscalYear("2026-27")
+	born := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tests := []struct {
+		name      string
+		dependent Dependent
+		accepted  bool
+		reason    DependentReason
+	}{
+		{"child under 18", Dependent{Relationship: RelationChild, BirthDate: born("2010-06-01")}, true, ReasonChildMinor},
+		{"child turning 18 on the first day", Dependent{Relationship: RelationChild, BirthDate: born("2008-04-01")}, false, ReasonChildAdult},
+		{"child turning 18 the day after", Dependent{Relationship: RelationChild, BirthDate: born("2008-04-02")}, true, ReasonChildMinor},
+		{"adult student", Dependent{Relationship: RelationChild, BirthDate: born("2005-01-01"), Student: true}, true, ReasonChildStudent},
+		{"adult disabled", Dependent{Relationship: RelationChild, BirthDate: born("1990-01-01"), Disabled: true}, true, ReasonChildDisabled},
+		{"child without birth date", Dependent{Relationship: RelationChild}, false, ReasonChildNoBirthDate},
+		{"student without birth date", Dependent{Relationship: RelationChild, Student: true}, false, ReasonChildNoBirthDate},
+		{"parent living together", Dependent{Relationship: RelationParent, LivesWith: true}, true, ReasonParentLivesWith},
+		{"parent living apart", Dependent{Relationship: RelationParent}, false, ReasonParentApart},
+		{"spouse without income", Dependent{Relationship: RelationSpouse}, true, ReasonSpouseNoIncome},
+		{"spouse who earns", Dependent{Relationship: RelationSpouse, HasIncome: true}, false, ReasonSpouseEarns},
+		{"sibling", Dependent{Relationship: "sibling"}, false, ReasonUnknownRelationship},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := CalculatePITInput{FiscalYear: fy, Dependents: []Dependent{tt.dependent}}
+			_, decisions := in.ClaimDependents()
+			if len(decisions) != 1 || decisions[0].Accepted != tt.accepted || decisions[0].Reason != tt.reason {
+				t.Errorf("expected %v (%s), got %+v", tt.accepted, tt.reason, decisions)
+			}
+		})
+	}
+
+	in := CalculatePITInput{FiscalYear: fy, DependentParents: 2, Childrens: 4, Dependents: []Dependent{
+		{Relationship: RelationParent, LivesWith: true},
+		{Relationship: RelationParent, LivesWith: true},
+		{Relationship: RelationParent, LivesWith: true},
+		{Relationship: RelationSpouse},
+		{Relationship: RelationSpouse},
+		{Relationship: RelationChild, BirthDate: born("2015-01-01")},
+	}}
+	claimed, decisions := in.ClaimDependents()
+	if claimed.DependentParents != 2 || claimed.DependentSpouse != 1 || claimed.Childrens != 1 || claimed.Dependents != nil {
+		t.Errorf("expected 2 parents, a spouse and a child claimed, got %+v", claimed)
+	}
+	if decisions[2].Reason != ReasonParentLimit || decisions[4].Reason != ReasonSpouseLimit {
+		t.Errorf("expected the third parent and second spouse over the limit, got %+v", decisions)
+	}
+	undated := CalculatePITInput{Dependents: []Dependent{{Relationship: RelationChild, BirthDate: born("2015-01-01")}}}
+	if _, decisions := undated.ClaimDependents(); decisions[0].Accepted || decisions[0].Reason != ReasonChildNoFiscalYear {
+		t.Errorf("expected a child in an undated input to be rejected, got %+v", decisions)
+	}
+	if unchanged, decisions := (CalculatePITInput{Childrens: 3}).ClaimDependents(); unchanged.Childrens != 3 || decisions != nil {
+		t.Errorf("expected an input without dependents unchanged, got %+v, %+v", unchanged, decisions)
+	}
+}
+
+func TestCalculatePIT_Dependents(t *testing.T) {
+	fy, _ := ParseFiscalYear("2026-27")
+	result, err := CalculatePIT(CalculatePITInput{
+		MonthlyIncome: 1000000, StartingMonth: 4, FiscalYear: fy,
+		// The counts are replaced by the dependents found eligible.
+		DependentParents: 2,
+		Dependents: []Dependent{
+			{Name: "Daw Mya", Relationship: RelationParent, LivesWith: true},
+			{Name: "U Ba", Relationship: RelationParent},
+			{Name: "Ko Ko", Relationship: RelationChild, BirthDate: time.Date(2005, 5, 1, 0, 0, 0, 0, time.UTC), Student: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ParentRelief != ParentReliefAmount || result.ChildRelief != ChildReliefAmount {
+		t.Errorf("expected one parent and one child relieved, got %f and %f", result.ParentRelief, result.ChildRelief)
+	}
+	if len(result.Dependents) != 3 || result.Dependents[1].Accepted || result.Dependents[1].Relief != 0 || result.Dependents[2].Relief != ChildReliefAmount {
+		t.Errorf("expected a decision on each dependent, got %+v", result.Dependents)
+	}
+}
+
+func TestCalculatePITInput_Normalize(t *testing.T) {
+	in := CalculatePITInput{
+		MonthlyIncome: 1000000,
+		Joined:        time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC),
+		Dependents: []Dependent{
+			{Name: "Daw Mya", Relationship: RelationParent, LivesWith: true},
+			{Name: "Ma Ma", Relationship: RelationSpouse},
+		},
+	}
+	got, err := in.Normalize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.StartingMonth != 6 || got.Months() != 10 || got.FiscalYear.Code() != "2026-27" {
+		t.Errorf("expected 10 months from June in FY 2026-27, got %d from %d in %q", got.Months(), got.StartingMonth, got.FiscalYear.Code())
+	}
+	if got.DependentParents != 1 || got.DependentSpouse != 1 || got.Dependents != nil {
+		t.Errorf("expected the dependents counted, got %+v", got)
+	}
+	again, err := got.Normalize()
+	if err != nil || !reflect.DeepEqual(again, got) {
+		t.Errorf("expected normalizing twice to change nothing, got %+v", again)
+	}
+
+	in.Left = in.Joined.AddDate(0, -1, 0)
+	if _, err := in.Normalize(); err == nil {
+		t.Error("expected an error for leaving before joining")
+	}
+}
+
+func TestCalculatePIT_Explanation(t *testing.T) {
+	result, err := CalculatePIT(CalculatePITInput{
+		MonthlyIncome: 10000000, Joined: time.Date(2026, time.June, 16, 0, 0, 0, 0, time.UTC),
+		Proration: ProrateCalendarDays, DependentParents: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var kinds []StepKind
+	for _, s := range result.Explanation {
+		kinds = append(kinds, s.Kind)
+	}
+	expected := []StepKind{StepMonths, StepProration, StepGross, StepBasicRelief, StepParentRelief, StepSpouseRelief,
+		StepChildRelief, StepSSBRelief, StepTotalRelief, StepTaxable, StepBracket, StepBracket, StepBracket, StepBracket,
+		StepBracket, StepBracket, StepTotalTax}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("expected steps %v, got %v", expected, kinds)
+	}
+
+	steps := result.Explanation
+	if steps[0].Count != 10 || steps[0].Month != 6 || steps[0].LastMonth != 3 {
+		t.Errorf("expected 10 months from June to March, got %+v", steps[0])
+	}
+	// The gross income is the full months plus the prorated ones.
+	if gross := steps[2].Base*steps[2].Count + steps[1].Amount; gross != result.GrossIncome || steps[2].Amount != gross {
+		t.Errorf("expected the gross income %f from its steps, got %+v and %+v", result.GrossIncome, steps[1], steps[2])
+	}
+	if !steps[3].Capped || steps[3].Amount != BasicReliefCap {
+		t.Errorf("expected the basic relief capped, got %+v", steps[3])
+	}
+	top := steps[len(steps)-2]
+	if top.Limit != 0 || top.Base != 14000000 || top.Rate != 0.25 || top.Amount != 3500000 {
+		t.Errorf("expected 14,000,000 taxed at 25%% in the open top bracket, got %+v", top)
+	}
+	var sum float64
+	for _, s := range steps {
+		if s.Kind == StepBracket {
+			sum += s.Amount
+		}
+	}
+	if last := steps[len(steps)-1]; last.Amount != result.TotalTax || sum != result.TotalTax {
+		t.Errorf("expected the bracket steps to sum to %f, got %f and %+v", result.TotalTax, sum, last)
+	}
+	if _, err := json.Marshal(result.Explanation); err != nil {
+		t.Errorf("expected the explanation to encode as JSON, got %v", err)
+	}
+}
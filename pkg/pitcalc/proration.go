@@ -0,0 +1,108 @@
+package pitcalc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Proration is how a month someone joined or left part way through is
+// counted. The zero value counts it in full.
+type Proration string
+
+const (
+	ProrateNone         Proration = ""
+	ProrateCalendarDays Proration = "calendar"
+	ProrateWorkingDays  Proration = "working"
+)
+
+// ParseProration resolves a proration name: "calendar", "working", or
+// empty (or "none") for full months.
+func ParseProration(s string) (Proration, error) {
+	switch p := Proration(strings.ToLower(strings.TrimSpace(s))); p {
+	case "none":
+		return ProrateNone, nil
+	case ProrateNone, ProrateCalendarDays, ProrateWorkingDays:
+		return p, nil
+	}
+	return "", fmt.Errorf("%w %q", ErrProration, s)
+}
+
+// ProratedMonth is a month someone was employed for only part of.
+type ProratedMonth struct {
+	// Month is the calendar month, 1 = January.
+	Month int64
+	// Days counts the days employed in the month, and DaysInMonth all its
+	// days, either calendar days or working days (Monday to Friday).
+	Days        int
+	DaysInMonth int
+	Basis       Proration
+	// Income is the monthly income paid for the days employed.
+	Income float64
+}
+
+// Fraction returns the share of the month employed.
+func (p ProratedMonth) Fraction() float64 {
+	return float64(p.Days) / float64(p.DaysInMonth)
+}
+
+// Prorate lists the first and last months of the employment when they were
+// worked only in part, with the days counted by the given basis. Income is
+// left for the caller to fill in. ProrateNone lists no months.
+func (e Employment) Prorate(basis Proration) []ProratedMonth {
+	if basis == ProrateNone {
+		return nil
+	}
+	count := daysBetween
+	if basis == ProrateWorkingDays {
+		count = workingDays
+	}
+
+	ends := []time.Time{date(e.Joined.Year(), e.Joined.Month(), 1)}
+	if last := date(e.Left.Year(), e.Left.Month(), 1); !last.Equal(ends[0]) {
+		ends = append(ends, last)
+	}
+	var out []ProratedMonth
+	for _, m := range ends {
+		monthEnd := m.AddDate(0, 1, -1)
+		from, to := m, monthEnd
+		if e.Joined.After(from) {
+			from = e.Joined
+		}
+		if e.Left.Before(to) {
+			to = e.Left
+		}
+		if from.Equal(m) && to.Equal(monthEnd) {
+			continue
+		}
+		out = append(out, ProratedMonth{
+			Month:       int64(m.Month()),
+			Days:        count(from, to),
+			DaysInMonth: count(m, monthEnd),
+			Basis:       basis,
+		})
+	}
+	return out
+}
+
+// ProratedMonths counts the months employed with a partial first or last
+// month as the share of it employed by the given basis.
+func (e Employment) ProratedMonths(basis Proration) float64 {
+	months := float64(e.Months)
+	for _, p := range e.Prorate(basis) {
+		months -= 1 - p.Fraction()
+	}
+	return months
+}
+
+// workingDays counts the days from one date to another, inclusive, that
+// fall from Monday to Friday. Public holidays are not taken into account.
+func workingDays(from, to time.Time) int {
+	n := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			n++
+		}
+	}
+	return n
+}
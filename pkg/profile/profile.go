@@ -0,0 +1,166 @@
+// Package profile stores the personal details people reuse between
+// calculations, such as dependents and SSB contributions, so both front-ends
+// can prefill their inputs. Several profiles can be kept, e.g. one for each
+// member of a family.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+const fileName = "profiles.json"
+
+// Profile holds saved defaults for one person.
+type Profile struct {
+	Name             string
+	EmployeeID       string    `json:",omitempty"`
+	DependentParents int64     `json:",omitempty"`
+	DependentSpouse  bool      `json:",omitempty"`
+	Children         int64     `json:",omitempty"`
+	SSB              float64   `json:",omitempty"`
+	Language         i18n.Lang `json:",omitempty"`
+	// Dependents, when set, lists the dependents in full; the calculator
+	// then decides which are eligible instead of using the counts.
+	Dependents []pitcalc.Dependent `json:",omitempty"`
+}
+
+// ErrNotFound is returned for an unknown profile name.
+var ErrNotFound = errors.New("profile not found")
+
+// Validate checks the profile's values are accepted by pitcalc.CalculatePIT.
+func (p Profile) Validate() error {
+	switch {
+	case strings.TrimSpace(p.Name) == "":
+		return errors.New("profile name is required")
+	case p.DependentParents < 0 || p.DependentParents > pitcalc.MaxDependentParents:
+		return fmt.Errorf("number of dependent parents must be between 0 and %d", pitcalc.MaxDependentParents)
+	case p.Children < 0:
+		return errors.New("number of children cannot be negative")
+	case p.SSB < 0:
+		return errors.New("yearly SSB contribution cannot be negative")
+	case p.Language != "" && !i18n.Available(p.Language):
+		return fmt.Errorf("unsupported language %q", p.Language)
+	}
+	return nil
+}
+
+// Apply copies the profile's dependents and SSB into a calculation input.
+func (p Profile) Apply(in pitcalc.CalculatePITInput) pitcalc.CalculatePITInput {
+	in.Dependents = p.Dependents
+	in.DependentParents = p.DependentParents
+	in.DependentSpouse = 0
+	if p.DependentSpouse {
+		in.DependentSpouse = 1
+	}
+	in.Childrens = p.Children
+	in.SSB = p.SSB
+	return in
+}
+
+// Store reads and writes the profiles file.
+type Store struct {
+	path string
+}
+
+// NewStore returns a store backed by the given file.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStore returns the store in the configuration directory.
+func DefaultStore() (*Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(filepath.Join(dir, fileName)), nil
+}
+
+// List returns all profiles sorted by name. A missing profiles file yields
+// no profiles.
+func (s *Store) List() ([]Profile, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profiles []Profile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("read profiles: %w", err)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles, nil
+}
+
+func (s *Store) save(profiles []Profile) error {
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(s.path, append(data, '\n'), 0644)
+}
+
+// Get returns the profile with the given name.
+func (s *Store) Get(name string) (Profile, error) {
+	profiles, err := s.List()
+	if err != nil {
+		return Profile{}, err
+	}
+	for _, p := range profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Profile{}, ErrNotFound
+}
+
+// Save adds the profile, or replaces the profile with the same name.
+func (s *Store) Save(p Profile) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	profiles, err := s.List()
+	if err != nil {
+		return err
+	}
+	for i := range profiles {
+		if profiles[i].Name == p.Name {
+			profiles[i] = p
+			return s.save(profiles)
+		}
+	}
+	return s.save(append(profiles, p))
+}
+
+// Delete removes the profile with the given name.
+func (s *Store) Delete(name string) error {
+	profiles, err := s.List()
+	if err != nil {
+		return err
+	}
+	for i, p := range profiles {
+		if p.Name == name {
+			return s.save(append(profiles[:i], profiles[i+1:]...))
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,96 @@
+package profile
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+func TestStore_SaveGetListDelete(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "nested", fileName))
+
+	profiles, err := s.List()
+	if err != nil || len(profiles) != 0 {
+		t.Fatalf("expected no profiles, got %d and %v", len(profiles), err)
+	}
+
+	for _, p := range []Profile{
+		{Name: "Mya", EmployeeID: "E-002", Children: 2, Language: i18n.MY},
+		{Name: "Aung", DependentParents: 2, DependentSpouse: true, SSB: 72000},
+	} {
+		if err := s.Save(p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := s.Save(Profile{Name: "  Mya ", Children: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	profiles, err = s.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "Aung" || profiles[1].Name != "Mya" {
+		t.Fatalf("expected Aung and Mya sorted by name, got %+v", profiles)
+	}
+
+	mya, err := s.Get("Mya")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mya.Children != 3 || mya.EmployeeID != "" {
+		t.Errorf("expected saving an existing name to replace the profile, got %+v", mya)
+	}
+
+	if err := s.Delete("Mya"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Get("Mya"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := s.Delete("Mya"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		valid   bool
+	}{
+		{"valid", Profile{Name: "Aung", DependentParents: 2, Language: i18n.EN}, true},
+		{"missing name", Profile{Name: " "}, false},
+		{"three parents", Profile{Name: "Aung", DependentParents: 3}, false},
+		{"negative children", Profile{Name: "Aung", Children: -1}, false},
+		{"negative SSB", Profile{Name: "Aung", SSB: -1}, false},
+		{"unknown language", Profile{Name: "Aung", Language: "FR"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.profile.Validate(); (err == nil) != tt.valid {
+				t.Errorf("expected valid %v, got %v", tt.valid, err)
+			}
+		})
+	}
+
+	s := NewStore(filepath.Join(t.TempDir(), fileName))
+	if err := s.Save(Profile{Name: ""}); err == nil {
+		t.Errorf("expected invalid profile to be rejected, got nil")
+	}
+}
+
+func TestProfile_Apply(t *testing.T) {
+	p := Profile{Name: "Aung", DependentParents: 1, DependentSpouse: true, Children: 2, SSB: 72000}
+	in := p.Apply(pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4, DependentParents: 2})
+
+	if in.MonthlyIncome != 1000000 || in.StartingMonth != 4 {
+		t.Errorf("expected income and months to be kept, got %+v", in)
+	}
+	if in.DependentParents != 1 || in.DependentSpouse != 1 || in.Childrens != 2 || in.SSB != 72000 {
+		t.Errorf("expected profile dependents and SSB, got %+v", in)
+	}
+}
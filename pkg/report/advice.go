@@ -0,0 +1,71 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// maxAllocations is how many of the best allocations AdviceTable lists
+// besides the claims as entered.
+const maxAllocations = 3
+
+// AdviceTable lays out the best ways of sharing the dependents between the
+// named taxpayers, and the claims as entered, as rows of cells: the rank,
+// each taxpayer's claim, the total tax, the saving and a note marking the
+// claims as entered.
+func AdviceTable(lang i18n.Lang, a *pitcalc.Advice, names []string) (headers []string, rows [][]string) {
+	headers = append([]string{"#"}, names...)
+	headers = append(headers, i18n.T(lang, "advice_total_tax"), i18n.T(lang, "advice_saving"), "")
+	for i, alloc := range a.Allocations {
+		if i >= maxAllocations && !alloc.Entered {
+			continue
+		}
+		row := []string{i18n.Digits(lang, fmt.Sprint(i+1))}
+		for _, in := range alloc.Inputs {
+			row = append(row, i18n.Tf(lang, "advice_parents", in.DependentParents)+", "+
+				i18n.Tf(lang, "advice_children", in.Childrens))
+		}
+		note := ""
+		if alloc.Entered {
+			note = i18n.T(lang, "advice_entered")
+		}
+		rows = append(rows, append(row,
+			i18n.FormatCurrency(lang, alloc.TotalTax), i18n.FormatCurrency(lang, alloc.Saving), note))
+	}
+	return headers, rows
+}
+
+// AdviceNotes describes the advice in sentences: for two taxpayers, what
+// the best way of sharing the dependents saves, then each named taxpayer's
+// SSB and lower-bracket advice.
+func AdviceNotes(lang i18n.Lang, a *pitcalc.Advice, names []string) []string {
+	money := func(v float64) string { return i18n.FormatCurrency(lang, v) }
+	var notes []string
+	if len(a.Current) > 1 {
+		if best := a.Allocations[0]; best.Saving > 0 {
+			notes = append(notes, i18n.Tf(lang, "advice_best", money(best.Saving)))
+		} else {
+			notes = append(notes, i18n.T(lang, "advice_best_entered"))
+		}
+	}
+	for i, c := range a.Contributions {
+		name := names[i]
+		switch {
+		case c.SSBRoom == 0:
+			notes = append(notes, i18n.Tf(lang, "advice_ssb_full", name))
+		case c.SSBSaving > 0:
+			notes = append(notes, i18n.Tf(lang, "advice_ssb", name, money(c.SSBRoom), money(c.SSBSaving)))
+		default:
+			notes = append(notes, i18n.Tf(lang, "advice_ssb_no_saving", name, money(c.SSBRoom)))
+		}
+		if c.LowerBracket > 0 {
+			rate := i18n.Digits(lang, fmt.Sprintf("%.0f%%", c.LowerRate*100))
+			notes = append(notes, i18n.Tf(lang, "advice_bracket", name, money(c.LowerBracket), rate, money(c.BracketSaving)))
+		} else {
+			notes = append(notes, i18n.Tf(lang, "advice_lowest", name))
+		}
+	}
+	return notes
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// Scenario is a named calculation kept for comparison with others.
+type Scenario struct {
+	Name   string
+	Input  pitcalc.CalculatePITInput
+	Output *pitcalc.CalculatePITOutput
+}
+
+// ComparisonRow is one compared figure across scenarios. Diffs holds each
+// value minus the first scenario's, so Diffs[0] is always zero.
+type ComparisonRow struct {
+	ID     string
+	Label  string
+	Values []float64
+	Diffs  []float64
+}
+
+// comparedFields lists the figures compared between scenarios, by
+// translation key.
+var comparedFields = []struct {
+	id    string
+	value func(c *pitcalc.CalculatePITOutput) float64
+}{
+	{"res_gross_income", func(c *pitcalc.CalculatePITOutput) float64 { return c.GrossIncome }},
+	{"res_basic_relief", func(c *pitcalc.CalculatePITOutput) float64 { return c.BasicRelief }},
+	{"res_parent_relief", func(c *pitcalc.CalculatePITOutput) float64 { return c.ParentRelief }},
+	{"res_spouse_relief", func(c *pitcalc.CalculatePITOutput) float64 { return c.SpouseRelief }},
+	{"res_child_relief", func(c *pitcalc.CalculatePITOutput) float64 { return c.ChildRelief }},
+	{"res_ssb_relief", func(c *pitcalc.CalculatePITOutput) float64 { return c.SSBRelief }},
+	{"res_total_reliefs", func(c *pitcalc.CalculatePITOutput) float64 { return c.TotalRelief }},
+	{"res_total_income", func(c *pitcalc.CalculatePITOutput) float64 { return c.TotalTexable }},
+	{"res_final_tax", func(c *pitcalc.CalculatePITOutput) float64 { return c.TotalTax }},
+}
+
+// Compare lines up the reliefs, taxable income and final tax of the
+// scenarios, with differences against the first scenario.
+func Compare(lang i18n.Lang, scenarios []Scenario) []ComparisonRow {
+	rows := make([]ComparisonRow, len(comparedFields))
+	for i, f := range comparedFields {
+		row := ComparisonRow{
+			ID:     f.id,
+			Label:  i18n.T(lang, f.id),
+			Values: make([]float64, len(scenarios)),
+			Diffs:  make([]float64, len(scenarios)),
+		}
+		for j, s := range scenarios {
+			row.Values[j] = f.value(s.Output)
+			row.Diffs[j] = row.Values[j] - row.Values[0]
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// HouseholdRows lines up the compared figures of a household's two spouses,
+// with the household's total as a third value. Diffs is not set.
+func HouseholdRows(lang i18n.Lang, out *pitcalc.HouseholdOutput) []ComparisonRow {
+	rows := make([]ComparisonRow, len(comparedFields))
+	for i, f := range comparedFields {
+		row := ComparisonRow{ID: f.id, Label: i18n.T(lang, f.id)}
+		total := 0.0
+		for _, s := range out.Spouses {
+			v := f.value(s)
+			row.Values = append(row.Values, v)
+			total += v
+		}
+		row.Values = append(row.Values, total)
+		rows[i] = row
+	}
+	return rows
+}
+
+// SignedCurrency formats a difference in kyat in the given language with an
+// explicit sign.
+func SignedCurrency(lang i18n.Lang, amount float64) string {
+	if amount > 0 {
+		return "+" + i18n.FormatCurrency(lang, amount)
+	}
+	return i18n.FormatCurrency(lang, amount)
+}
+
+// comparisonFormatters return the value and difference formatters for
+// comparison cells in the given language.
+func comparisonFormatters(lang i18n.Lang) (value, diff func(float64) string) {
+	value = func(v float64) string { return i18n.FormatCurrency(lang, v) }
+	diff = func(v float64) string { return SignedCurrency(lang, v) }
+	return value, diff
+}
+
+// ComparisonFormats lists the formats a scenario comparison can be exported
+// in.
+func ComparisonFormats() []Format {
+	return []Format{FormatTXT, FormatJSON, FormatCSV, FormatMarkdown}
+}
+
+// comparisonHeader returns the column headings: the item, then each
+// scenario followed by its difference from the first.
+func comparisonHeader(lang i18n.Lang, scenarios []Scenario) []string {
+	header := []string{i18n.T(lang, "res_item")}
+	for i, s := range scenarios {
+		header = append(header, s.Name)
+		if i > 0 {
+			header = append(header, fmt.Sprintf("%s %s", i18n.T(lang, "res_diff"), s.Name))
+		}
+	}
+	return header
+}
+
+func comparisonCells(row ComparisonRow, format func(float64) string, diff func(float64) string) []string {
+	cells := []string{row.Label}
+	for i := range row.Values {
+		cells = append(cells, format(row.Values[i]))
+		if i > 0 {
+			cells = append(cells, diff(row.Diffs[i]))
+		}
+	}
+	return cells
+}
+
+// ComparisonText renders the comparison as an aligned plain text table.
+func ComparisonText(lang i18n.Lang, scenarios []Scenario) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", i18n.T(lang, "compare_title"))
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, strings.Join(comparisonHeader(lang, scenarios), "\t")+"\t")
+	value, diff := comparisonFormatters(lang)
+	for _, row := range Compare(lang, scenarios) {
+		fmt.Fprintln(tw, strings.Join(comparisonCells(row, value, diff), "\t")+"\t")
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// ComparisonMarkdown renders the comparison as a Markdown table.
+func ComparisonMarkdown(lang i18n.Lang, scenarios []Scenario) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", i18n.T(lang, "compare_title"))
+	header := comparisonHeader(lang, scenarios)
+	for _, h := range header {
+		fmt.Fprintf(&b, "| %s ", markdownCell(h))
+	}
+	b.WriteString("|\n|:---")
+	b.WriteString(strings.Repeat("|---:", len(header)-1))
+	b.WriteString("|\n")
+	value, diff := comparisonFormatters(lang)
+	for _, row := range Compare(lang, scenarios) {
+		for _, c := range comparisonCells(row, value, diff) {
+			fmt.Fprintf(&b, "| %s ", markdownCell(c))
+		}
+		b.WriteString("|\n")
+	}
+	return b.String()
+}
+
+func writeComparisonCSV(w io.Writer, lang i18n.Lang, scenarios []Scenario) error {
+	number := func(v float64) string { return fmt.Sprintf("%.2f", v) }
+	cw := csv.NewWriter(w)
+	cw.Write(comparisonHeader(lang, scenarios))
+	for _, row := range Compare(lang, scenarios) {
+		cw.Write(comparisonCells(row, number, number))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeComparisonJSON(w io.Writer, lang i18n.Lang, scenarios []Scenario) error {
+	type jsonScenario struct {
+		Name   string
+		Input  pitcalc.CalculatePITInput
+		Output any
+	}
+	out := struct {
+		Scenarios []jsonScenario
+		Rows      []ComparisonRow
+	}{Rows: Compare(lang, scenarios)}
+	for _, s := range scenarios {
+		out.Scenarios = append(out.Scenarios, jsonScenario{s.Name, s.Input, jsonOutput(s.Input, s.Output)})
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// WriteComparison renders a scenario comparison in one of the
+// ComparisonFormats.
+func WriteComparison(w io.Writer, f Format, lang i18n.Lang, scenarios []Scenario) error {
+	if len(scenarios) == 0 {
+		return fmt.Errorf("no scenarios to compare")
+	}
+	switch f {
+	case FormatTXT:
+		_, err := io.WriteString(w, ComparisonText(lang, scenarios))
+		return err
+	case FormatMarkdown:
+		_, err := io.WriteString(w, ComparisonMarkdown(lang, scenarios))
+		return err
+	case FormatCSV:
+		return writeComparisonCSV(w, lang, scenarios)
+	case FormatJSON:
+		return writeComparisonJSON(w, lang, scenarios)
+	default:
+		return fmt.Errorf("unsupported comparison format %q", f)
+	}
+}
+
+// WriteComparisonFile renders a scenario comparison into the named file,
+// replacing it atomically.
+func WriteComparisonFile(filename string, f Format, lang i18n.Lang, scenarios []Scenario) error {
+	var buf bytes.Buffer
+	if err := WriteComparison(&buf, f, lang, scenarios); err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(filename, buf.Bytes(), 0644)
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// ExplainedStep is a step of a calculation described for people: what was
+// worked out, how, and the result, e.g. "Basic relief", "20% of
+// 12,000,000.00 MMK, at most 10,000,000.00 MMK" and "2,400,000.00 MMK".
+type ExplainedStep struct {
+	Label   string
+	Working string
+	Amount  string
+}
+
+// percent writes a rate as a percentage, e.g. "5%".
+func percent(lang i18n.Lang, rate float64) string {
+	return i18n.Digits(lang, strconv.FormatFloat(math.Round(rate*10000)/100, 'f', -1, 64)+"%")
+}
+
+// Explain describes the steps of a calculation, in order, in the given
+// language.
+func Explain(lang i18n.Lang, c *pitcalc.CalculatePITOutput) []ExplainedStep {
+	t := func(id string) string { return i18n.T(lang, id) }
+	money := func(v float64) string { return i18n.FormatCurrency(lang, v) }
+
+	var out []ExplainedStep
+	prorated := false
+	for _, s := range c.Explanation {
+		step := ExplainedStep{Amount: money(s.Amount)}
+		switch s.Kind {
+		case pitcalc.StepMonths:
+			step.Label = t("res_months")
+			step.Working = i18n.Tf(lang, "explain_months", i18n.MonthName(lang, s.Month), i18n.MonthName(lang, s.LastMonth))
+			step.Amount = i18n.Digits(lang, fmt.Sprint(s.Count))
+		case pitcalc.StepProration:
+			prorated = true
+			step.Label = i18n.Tf(lang, "explain_proration", i18n.MonthName(lang, s.Month))
+			step.Working = i18n.Tf(lang, "explain_proration_rule", money(s.Base), int64(s.Count), int64(s.Of))
+		case pitcalc.StepGross:
+			step.Label = t("res_gross_income")
+			id := "explain_gross"
+			if prorated {
+				id = "explain_gross_part"
+			}
+			step.Working = i18n.Tf(lang, id, money(s.Base), int64(s.Count))
+		case pitcalc.StepBasicRelief:
+			step.Label = t("explain_basic")
+			id := "explain_basic_rule"
+			if s.Capped {
+				id = "explain_basic_capped"
+			}
+			step.Working = i18n.Tf(lang, id, percent(lang, s.Rate), money(s.Base), money(s.Cap))
+		case pitcalc.StepParentRelief:
+			step.Label = t("explain_parent")
+			step.Working = i18n.Tf(lang, "explain_parent_rule", int64(s.Count), money(s.Base), int64(s.Cap))
+		case pitcalc.StepSpouseRelief:
+			step.Label = t("explain_spouse")
+			step.Working = i18n.Tf(lang, "explain_spouse_rule", int64(s.Count), money(s.Base))
+		case pitcalc.StepChildRelief:
+			step.Label = t("explain_child")
+			step.Working = i18n.Tf(lang, "explain_child_rule", int64(s.Count), money(s.Base))
+		case pitcalc.StepSSBRelief:
+			step.Label = t("explain_ssb")
+			step.Working = t("explain_ssb_rule")
+		case pitcalc.StepTotalRelief:
+			step.Label = t("res_total_reliefs")
+			step.Working = t("explain_total_relief")
+		case pitcalc.StepTaxable:
+			step.Label = t("res_total_income")
+			id := "explain_taxable"
+			if s.Capped {
+				id = "explain_taxable_zero"
+			}
+			step.Working = i18n.Tf(lang, id, money(s.Base), money(s.Of))
+		case pitcalc.StepBracket:
+			step.Label = i18n.Tf(lang, "explain_bracket", money(s.Start), money(s.Limit))
+			if s.Limit == 0 {
+				step.Label = i18n.Tf(lang, "explain_bracket_top", money(s.Start))
+			}
+			step.Working = i18n.Tf(lang, "explain_bracket_rule", percent(lang, s.Rate), money(s.Base))
+		case pitcalc.StepTotalTax:
+			step.Label = t("cli_total_tax")
+			step.Working = t("explain_total_tax")
+		default:
+			continue
+		}
+		out = append(out, step)
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// FilenameVars are the values substituted into export filename patterns.
+// Year is the calculation's fiscal year; when it is not set, the year
+// containing Time is used.
+type FilenameVars struct {
+	Time time.Time
+	Name string
+	Year pitcalc.FiscalYear
+}
+
+// FiscalYearLabel returns the code of the fiscal year containing t, e.g.
+// "2026-27" for any date from April 2026 to March 2027.
+func FiscalYearLabel(t time.Time) string {
+	return pitcalc.FiscalYearOf(t).Code()
+}
+
+// sanitizeFilename replaces characters that are unsafe in file names across
+// platforms, and whitespace, with underscores.
+func sanitizeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(`/\:*?"<>|`, r), unicode.IsSpace(r), unicode.IsControl(r):
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(s))
+}
+
+// ExpandFilename substitutes the placeholders in pattern:
+//
+//	{date}  the date as YYYY-MM-DD
+//	{time}  the time as HHMMSS
+//	{name}  the employee name
+//	{fy}    the fiscal year, e.g. 2026-27
+//
+// Substituted values are sanitized so they cannot introduce directories. A
+// pattern that expands to nothing falls back to "PIT_Report".
+func ExpandFilename(pattern string, vars FilenameVars) string {
+	fy := vars.Year.Code()
+	if vars.Year.IsZero() {
+		fy = FiscalYearLabel(vars.Time)
+	}
+	r := strings.NewReplacer(
+		"{date}", vars.Time.Format("2006-01-02"),
+		"{time}", vars.Time.Format("150405"),
+		"{name}", sanitizeFilename(vars.Name),
+		"{fy}", fy,
+	)
+	name := strings.Trim(r.Replace(strings.TrimSpace(pattern)), "_-. ")
+	if name == "" {
+		return "PIT_Report"
+	}
+	return name
+}
+
+// ExportPath joins the export directory and the expanded filename pattern,
+// adding the extension ext (including the dot).
+func ExportPath(dir, pattern string, vars FilenameVars, ext string) string {
+	return filepath.Join(dir, ExpandFilename(pattern, vars)+ext)
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+type htmlRow struct {
+	Label  string
+	Amount string
+}
+
+type htmlBracket struct {
+	From   string
+	To     string
+	Amount string
+}
+
+type htmlReport struct {
+	Lang     string
+	T        func(id string) string
+	Income   []htmlRow
+	Reliefs  []htmlRow
+	Total    htmlRow
+	Deps     []DependentDecision
+	Tax      string
+	Brackets []htmlBracket
+}
+
+// htmlTemplate is self-contained: styles are inlined so the file can be
+// attached to an email or pasted into a wiki without external assets.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{call .T "title"}}</title>
+<style>
+  body { font-family: "Segoe UI", "Noto Sans", "Noto Sans Myanmar", "Myanmar Text", Padauk, sans-serif; color: #1E293B; max-width: 720px; margin: 2rem auto; padding: 0 1rem; }
+  h1 { border-bottom: 3px solid #10B981; padding-bottom: .5rem; }
+  h2 { color: #10B981; margin-top: 2rem; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { border: 1px solid #CBD5E1; padding: .4rem .8rem; }
+  th { background: #F1F5F9; text-align: left; }
+  td.amount { text-align: right; font-variant-numeric: tabular-nums; }
+  tr.total td { font-weight: bold; }
+  .final { background: #1E293B; color: #F8FAFC; padding: 1rem 1.5rem; font-size: 1.25rem; }
+  .final strong { color: #10B981; }
+</style>
+</head>
+<body>
+<h1>{{call .T "title"}}</h1>
+
+<h2>{{call .T "res_income"}}</h2>
+<table>
+{{- range .Income}}
+  <tr><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- end}}
+</table>
+
+<h2>{{call .T "res_reliefs"}}</h2>
+<table>
+  <tr><th>{{call .T "res_item"}}</th><th>{{call .T "res_amount"}}</th></tr>
+{{- range .Reliefs}}
+  <tr><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- end}}
+  <tr class="total"><td>{{.Total.Label}}</td><td class="amount">{{.Total.Amount}}</td></tr>
+</table>
+{{- if .Deps}}
+
+<h2>{{call .T "res_dependents"}}</h2>
+<table>
+  <tr><th>{{call .T "res_item"}}</th><th>{{call .T "res_dependents_decision"}}</th><th>{{call .T "res_amount"}}</th></tr>
+{{- range .Deps}}
+  <tr><td>{{.Label}}</td><td>{{.Decision}}</td><td class="amount">{{.Relief}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<p class="final">{{call .T "res_final_tax"}}: <strong>{{.Tax}}</strong></p>
+
+<h2>{{call .T "res_brackets"}}</h2>
+<table>
+  <tr><th>{{call .T "res_from"}}</th><th>{{call .T "res_to"}}</th><th>{{call .T "res_tax_amount"}}</th></tr>
+{{- range .Brackets}}
+  <tr><td class="amount">{{.From}}</td><td class="amount">{{.To}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+func writeHTML(w io.Writer, lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	t := func(id string) string { return i18n.T(lang, id) }
+	money := func(v float64) string { return i18n.FormatCurrency(lang, v) }
+
+	income := []htmlRow{
+		{t("res_months"), Period(lang, in)},
+		{t("res_gross_income"), money(c.GrossIncome)},
+	}
+	for _, p := range Proration(lang, c) {
+		income = append(income, htmlRow{p.Label, p.Income})
+	}
+	data := htmlReport{
+		Lang:   strings.ToLower(string(lang)),
+		T:      t,
+		Income: append(income, htmlRow{t("res_total_income"), money(c.TotalTexable)}),
+		Reliefs: []htmlRow{
+			{t("res_basic_relief"), money(c.BasicRelief)},
+			{t("res_parent_relief"), money(c.ParentRelief)},
+			{t("res_spouse_relief"), money(c.SpouseRelief)},
+			{t("res_child_relief"), money(c.ChildRelief)},
+			{t("res_ssb_relief"), money(c.SSBRelief)},
+		},
+		Total: htmlRow{t("res_total_reliefs"), money(c.TotalRelief)},
+		Deps:  Dependents(lang, c),
+		Tax:   money(c.TotalTax),
+	}
+	for _, v := range sortedBreakdown(c) {
+		data.Brackets = append(data.Brackets, htmlBracket{
+			From:   money(v.Start),
+			To:     limitLabel(lang, v.Limit),
+			Amount: money(v.Amount),
+		})
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+// HTML renders the calculation as a self-contained HTML document.
+func HTML(lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) (string, error) {
+	var b strings.Builder
+	if err := writeHTML(&b, lang, in, c); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// markdownCell escapes characters that would break a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// Markdown renders the calculation as a Markdown document with tables for
+// the reliefs and the bracket breakdown.
+func Markdown(lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) string {
+	t := func(id string) string { return i18n.T(lang, id) }
+	money := func(v float64) string { return i18n.FormatCurrency(lang, v) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t("title"))
+
+	fmt.Fprintf(&b, "## %s\n\n", t("res_income"))
+	fmt.Fprintf(&b, "- **%s:** %s\n", t("res_months"), Period(lang, in))
+	fmt.Fprintf(&b, "- **%s:** %s\n", t("res_gross_income"), money(c.GrossIncome))
+	for _, p := range Proration(lang, c) {
+		fmt.Fprintf(&b, "  - %s: %s\n", p.Label, p.Income)
+	}
+	fmt.Fprintf(&b, "- **%s:** %s\n\n", t("res_total_income"), money(c.TotalTexable))
+
+	fmt.Fprintf(&b, "## %s\n\n", t("res_reliefs"))
+	fmt.Fprintf(&b, "| %s | %s |\n|:---|---:|\n", markdownCell(t("res_item")), markdownCell(t("res_amount")))
+	for _, r := range []struct {
+		id     string
+		amount float64
+	}{
+		{"res_basic_relief", c.BasicRelief},
+		{"res_parent_relief", c.ParentRelief},
+		{"res_spouse_relief", c.SpouseRelief},
+		{"res_child_relief", c.ChildRelief},
+		{"res_ssb_relief", c.SSBRelief},
+	} {
+		fmt.Fprintf(&b, "| %s | %s |\n", markdownCell(t(r.id)), money(r.amount))
+	}
+	fmt.Fprintf(&b, "| **%s** | **%s** |\n\n", markdownCell(t("res_total_reliefs")), money(c.TotalRelief))
+
+	if deps := Dependents(lang, c); len(deps) > 0 {
+		fmt.Fprintf(&b, "## %s\n\n", t("res_dependents"))
+		fmt.Fprintf(&b, "| %s | %s | %s |\n|:---|:---|---:|\n",
+			markdownCell(t("res_item")), markdownCell(t("res_dependents_decision")), markdownCell(t("res_amount")))
+		for _, d := range deps {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(d.Label), markdownCell(d.Decision), d.Relief)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "## %s\n\n**%s**\n\n", t("res_final_tax"), money(c.TotalTax))
+
+	fmt.Fprintf(&b, "## %s\n\n", t("res_brackets"))
+	fmt.Fprintf(&b, "| %s | %s | %s |\n|---:|---:|---:|\n",
+		markdownCell(t("res_from")), markdownCell(t("res_to")), markdownCell(t("res_tax_amount")))
+	for _, v := range sortedBreakdown(c) {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n",
+			money(v.Start), markdownCell(limitLabel(lang, v.Limit)), money(v.Amount))
+	}
+	return b.String()
+}
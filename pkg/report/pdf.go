@@ -0,0 +1,229 @@
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"strings"
+
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pdf"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+//go:embed fonts/NotoSansMyanmar-Regular.ttf
+var myanmarFont []byte
+
+var (
+	pdfText    = pdf.Color{R: 0x1E, G: 0x29, B: 0x3B} // Slate 800
+	pdfMuted   = pdf.Color{R: 0x47, G: 0x55, B: 0x69} // Slate 600
+	pdfPrimary = pdf.Color{R: 0x10, G: 0xB9, B: 0x81} // Emerald Green
+	pdfBand    = pdf.Color{R: 0x1E, G: 0x29, B: 0x3B} // Slate 800
+	pdfLight   = pdf.Color{R: 0xF8, G: 0xFA, B: 0xFC} // Slate 50
+)
+
+const (
+	pdfMargin   = 50.0
+	pdfPadding  = 14.0
+	pdfLineStep = 18.0
+	pdfBodySize = 10.5
+)
+
+// newPDFDocument creates a document with Latin text set in Go Regular and
+// Burmese text in Noto Sans Myanmar.
+func newPDFDocument() (*pdf.Document, error) {
+	latin, err := pdf.ParseFont("GoRegular", goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	myanmar, err := pdf.ParseFont("NotoSansMyanmar-Regular", myanmarFont)
+	if err != nil {
+		return nil, err
+	}
+	return pdf.New(latin, myanmar), nil
+}
+
+type pdfRow struct {
+	label string
+	value string
+}
+
+// pdfPage tracks where the next thing is drawn in a document, starting a new
+// page when it would run into the bottom margin.
+type pdfPage struct {
+	doc *pdf.Document
+	y   float64
+}
+
+// bottom is the lowest y position drawing may reach on a page.
+func (p *pdfPage) bottom() float64 {
+	return p.doc.Height - pdfMargin
+}
+
+// need starts a new page unless h points fit above the bottom margin. It
+// reports whether it started one.
+func (p *pdfPage) need(h float64) bool {
+	if p.y+h <= p.bottom() {
+		return false
+	}
+	p.doc.AddPage()
+	p.y = pdfMargin
+	return true
+}
+
+// wrapPDFText splits text into lines no wider than width at the given size,
+// breaking between words. A word wider than width is given a line of its
+// own.
+func wrapPDFText(doc *pdf.Document, text string, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		next := word
+		if line != "" {
+			next = line + " " + word
+		}
+		if line != "" && doc.TextWidth(next, size) > width {
+			lines = append(lines, line)
+			next = word
+		}
+		line = next
+	}
+	return append(lines, line)
+}
+
+// drawPDFBox draws a titled, bordered box of label/value rows. A label too
+// long for the space beside its value is wrapped, and a box too long for the
+// page is continued, under its title, on the next.
+func drawPDFBox(p *pdfPage, title string, rows []pdfRow, total pdfRow) {
+	doc := p.doc
+	x := pdfMargin
+	w := doc.Width - 2*pdfMargin
+
+	var lines []pdfRow
+	for _, r := range append(rows, pdfRow{}, total) {
+		width := w - 2*pdfPadding - doc.TextWidth(r.value, pdfBodySize) - pdfPadding
+		for i, label := range wrapPDFText(doc, r.label, pdfBodySize, width) {
+			if i > 0 {
+				r.value = ""
+			}
+			lines = append(lines, pdfRow{label, r.value})
+		}
+	}
+
+	for len(lines) > 0 {
+		p.need(pdfPadding*2 + pdfLineStep*2)
+		fit := int((p.bottom()-p.y-pdfPadding*2)/pdfLineStep) - 1
+		part := lines[:max(1, min(fit, len(lines)))]
+		lines = lines[len(part):]
+
+		h := pdfPadding*2 + pdfLineStep*float64(len(part)+1)
+		doc.Rect(x, p.y, w, h, nil, &pdfMuted)
+		line := p.y + pdfPadding + 12
+		doc.Text(x+pdfPadding, line, 13, pdfPrimary, title)
+		line += pdfLineStep * 1.5
+		for _, r := range part {
+			if r.label != "" {
+				doc.Text(x+pdfPadding, line, pdfBodySize, pdfText, r.label)
+			}
+			if r.value != "" {
+				doc.Text(x+w-pdfPadding-doc.TextWidth(r.value, pdfBodySize), line, pdfBodySize, pdfText, r.value)
+			}
+			line += pdfLineStep
+		}
+		p.y += h + 16
+	}
+}
+
+// PDF renders the calculation as a printable A4 document with the same
+// sections as the terminal result view: income, reliefs, the dependents when
+// listed, final tax and the bracket table. It runs onto further pages as
+// needed.
+func PDF(lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) ([]byte, error) {
+	t := func(id string) string { return i18n.T(lang, id) }
+	money := func(v float64) string { return i18n.FormatCurrency(lang, v) }
+
+	doc, err := newPDFDocument()
+	if err != nil {
+		return nil, err
+	}
+	doc.AddPage()
+	p := &pdfPage{doc: doc, y: pdfMargin + 20}
+
+	doc.Text(pdfMargin, p.y, 20, pdfText, t("title"))
+	p.y += 12
+	doc.Line(pdfMargin, p.y, doc.Width-pdfMargin, p.y, 1.5, pdfPrimary)
+	p.y += 20
+
+	// Income Box
+	income := []pdfRow{
+		{t("res_months"), Period(lang, in)},
+		{t("res_gross_income"), money(c.GrossIncome)},
+	}
+	for _, pr := range Proration(lang, c) {
+		income = append(income, pdfRow{pr.Label, pr.Income})
+	}
+	drawPDFBox(p, t("res_income"), income,
+		pdfRow{t("res_total_income"), money(c.TotalTexable)})
+
+	// Reliefs Box
+	drawPDFBox(p, t("res_reliefs"),
+		[]pdfRow{
+			{t("res_basic_relief"), money(c.BasicRelief)},
+			{t("res_parent_relief"), money(c.ParentRelief)},
+			{t("res_spouse_relief"), money(c.SpouseRelief)},
+			{t("res_child_relief"), money(c.ChildRelief)},
+			{t("res_ssb_relief"), money(c.SSBRelief)},
+		},
+		pdfRow{t("res_total_reliefs"), money(c.TotalRelief)})
+
+	// Dependents Box
+	if deps := Dependents(lang, c); len(deps) > 0 {
+		var rows []pdfRow
+		for _, d := range deps {
+			rows = append(rows, pdfRow{d.Label + " – " + d.Decision, d.Relief})
+		}
+		drawPDFBox(p, t("res_dependents"), rows,
+			pdfRow{t("res_dependents_relief"), money(c.ParentRelief + c.SpouseRelief + c.ChildRelief)})
+	}
+
+	// Final Result Band
+	w := doc.Width - 2*pdfMargin
+	p.need(40)
+	doc.Rect(pdfMargin, p.y, w, 40, &pdfBand, nil)
+	doc.Text(pdfMargin+pdfPadding, p.y+25, 13, pdfLight, t("res_final_tax"))
+	tax := money(c.TotalTax)
+	doc.Text(pdfMargin+w-pdfPadding-doc.TextWidth(tax, 13), p.y+25, 13, pdfPrimary, tax)
+	p.y += 40 + 24
+
+	// Bracket Table, with its header repeated on each page it runs onto.
+	col := w / 3
+	header := func() {
+		for i, h := range []string{t("res_from"), t("res_to"), t("res_tax_amount")} {
+			doc.Text(pdfMargin+col*float64(i)+pdfPadding, p.y, pdfBodySize, pdfMuted, h)
+		}
+		p.y += 8
+		doc.Line(pdfMargin, p.y, doc.Width-pdfMargin, p.y, 0.75, pdfMuted)
+		p.y += pdfLineStep
+	}
+	if p.need(8 + pdfLineStep*2) {
+		p.y += pdfLineStep
+	}
+	header()
+	for _, v := range sortedBreakdown(c) {
+		if p.need(pdfLineStep) {
+			p.y += pdfLineStep
+			header()
+		}
+		for i, cell := range []string{money(v.Start), limitLabel(lang, v.Limit), money(v.Amount)} {
+			doc.Text(pdfMargin+col*float64(i)+pdfPadding, p.y, pdfBodySize, pdfText, cell)
+		}
+		p.y += pdfLineStep
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,384 @@
+// Package report renders personal income tax calculations into the export
+// formats shared by the command line and terminal UI front-ends.
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+// Format identifies an export file format. Its value is also the file
+// extension.
+type Format string
+
+const (
+	FormatTXT      Format = "txt"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatPDF      Format = "pdf"
+	FormatXLSX     Format = "xlsx"
+)
+
+// Formats lists the supported export formats.
+func Formats() []Format {
+	return []Format{FormatTXT, FormatJSON, FormatCSV, FormatMarkdown, FormatHTML, FormatPDF, FormatXLSX}
+}
+
+// ParseFormat resolves a format name or file extension, with or without a
+// leading dot.
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "."))
+	switch name {
+	case "markdown":
+		return FormatMarkdown, nil
+	case "htm":
+		return FormatHTML, nil
+	}
+	for _, f := range Formats() {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported export format %q", s)
+}
+
+// Currency formats an amount in kyat with thousands separators and Western
+// digits, for plain text and machine-readable output. Use
+// i18n.FormatCurrency for amounts shown in the user's language.
+func Currency(amount float64) string {
+	return i18n.FormatCurrency(i18n.EN, amount)
+}
+
+// normalized returns the input as the calculation counted it, with the
+// dependent counts and months it was worked out from; see
+// pitcalc.CalculatePITInput.Normalize. An input the calculation would have
+// rejected is returned as it is.
+func normalized(in pitcalc.CalculatePITInput) pitcalc.CalculatePITInput {
+	if n, err := in.Normalize(); err == nil {
+		return n
+	}
+	return in
+}
+
+// Period describes the months counted, e.g. "9 (July – March)", followed by
+// the fiscal year when it is set: "9 (July – March, FY 2026-27)".
+func Period(lang i18n.Lang, in pitcalc.CalculatePITInput) string {
+	in = normalized(in)
+	months := fmt.Sprintf("%s – %s", i18n.MonthName(lang, in.StartingMonth), i18n.MonthName(lang, in.LastMonth()))
+	if !in.FiscalYear.IsZero() {
+		months += ", " + FiscalYear(lang, in.FiscalYear)
+	}
+	return fmt.Sprintf("%s (%s)", i18n.Digits(lang, fmt.Sprint(in.Months())), months)
+}
+
+// FiscalYear names a fiscal year in the given language, e.g. "FY 2026-27".
+func FiscalYear(lang i18n.Lang, fy pitcalc.FiscalYear) string {
+	id := "fiscal_year"
+	if fy.Interim() {
+		id = "fiscal_year_interim"
+	}
+	return i18n.Tf(lang, id, i18n.Digits(lang, fy.Code()))
+}
+
+// ProratedMonth is a month counted in part: its name and the days employed,
+// e.g. "June (11 of 30 days)", and the income counted for it.
+type ProratedMonth struct {
+	Label  string
+	Income string
+}
+
+// Proration describes the months of a calculation counted in part, in the
+// given language.
+func Proration(lang i18n.Lang, c *pitcalc.CalculatePITOutput) []ProratedMonth {
+	var out []ProratedMonth
+	for _, p := range c.Proration {
+		id := "proration_calendar"
+		if p.Basis == pitcalc.ProrateWorkingDays {
+			id = "proration_working"
+		}
+		out = append(out, ProratedMonth{
+			Label:  i18n.Tf(lang, id, i18n.MonthName(lang, p.Month), p.Days, p.DaysInMonth),
+			Income: i18n.FormatCurrency(lang, p.Income),
+		})
+	}
+	return out
+}
+
+// DependentLabel names a dependent with their relationship, e.g.
+// "Daw Mya (Parent)", or only the relationship when they have no name.
+func DependentLabel(lang i18n.Lang, d pitcalc.Dependent) string {
+	relation := "dep_other"
+	switch d.Relationship {
+	case pitcalc.RelationParent, pitcalc.RelationSpouse, pitcalc.RelationChild:
+		relation = "dep_" + string(d.Relationship)
+	}
+	if d.Name == "" {
+		return i18n.T(lang, relation)
+	}
+	return fmt.Sprintf("%s (%s)", d.Name, i18n.T(lang, relation))
+}
+
+// DependentDecision describes the decision on a listed dependent: who they
+// are, e.g. "Daw Mya (Parent)", whether they were accepted and why, and the
+// relief allowed for them.
+type DependentDecision struct {
+	Label    string
+	Decision string
+	Relief   string
+}
+
+// Dependents describes the decisions on the dependents listed for a
+// calculation, in the given language.
+func Dependents(lang i18n.Lang, c *pitcalc.CalculatePITOutput) []DependentDecision {
+	var out []DependentDecision
+	for _, d := range c.Dependents {
+		id := "dep_rejected"
+		if d.Accepted {
+			id = "dep_accepted"
+		}
+		out = append(out, DependentDecision{
+			Label:    DependentLabel(lang, d.Dependent),
+			Decision: i18n.Tf(lang, id, i18n.T(lang, "dep_"+string(d.Reason))),
+			Relief:   i18n.FormatCurrency(lang, d.Relief),
+		})
+	}
+	return out
+}
+
+// calcErrors maps the engine's errors to the catalogue messages describing
+// them.
+var calcErrors = []struct {
+	err error
+	id  string
+}{
+	{pitcalc.ErrIncome, "calc_income"},
+	{pitcalc.ErrProration, "calc_proration"},
+	{pitcalc.ErrStartingMonth, "calc_start_month"},
+	{pitcalc.ErrEndingMonth, "calc_end_month"},
+	{pitcalc.ErrMonthNotInYear, "err_month_year"},
+	{pitcalc.ErrEndBeforeStart, "err_end_month"},
+	{pitcalc.ErrNegativeParents, "calc_parents_negative"},
+	{pitcalc.ErrSpouse, "calc_spouse"},
+	{pitcalc.ErrNegativeChildren, "calc_children"},
+	{pitcalc.ErrNegativeSSB, "calc_ssb"},
+	{pitcalc.ErrNoFiscalYear, "calc_no_fy"},
+	{pitcalc.ErrNotEmployed, "calc_not_employed"},
+	{pitcalc.ErrHouseholdNegative, "calc_household_negative"},
+	{pitcalc.ErrHouseholdClaims, "calc_household_claims"},
+	{pitcalc.ErrEarningSpouse, "calc_earning_spouse"},
+}
+
+// ErrorMessage describes an error from the engine in the given language.
+// Errors it does not know are described by their own text.
+func ErrorMessage(lang i18n.Lang, err error) string {
+	var claim *pitcalc.ClaimError
+	switch {
+	case errors.As(err, &claim) && claim.Spouse != 0:
+		return i18n.Tf(lang, "calc_listed_twice", claim.Spouse, claim.Name)
+	case errors.As(err, &claim):
+		return i18n.Tf(lang, "calc_claimed_twice", claim.Name)
+	case errors.Is(err, pitcalc.ErrTooManyParents):
+		return i18n.Tf(lang, "calc_parents_max", pitcalc.MaxDependentParents)
+	case errors.Is(err, pitcalc.ErrHouseholdParents):
+		return i18n.Tf(lang, "calc_household_parents", 2*pitcalc.MaxDependentParents)
+	}
+	for _, e := range calcErrors {
+		if errors.Is(err, e.err) {
+			return i18n.T(lang, e.id)
+		}
+	}
+	return err.Error()
+}
+
+// sortedBreakdown returns the bracket breakdown ordered by bracket start.
+func sortedBreakdown(c *pitcalc.CalculatePITOutput) []struct {
+	Start  float64
+	Limit  float64
+	Rate   float64
+	Amount float64
+} {
+	breakdown := c.TaxBreakdown
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Start < breakdown[j].Start
+	})
+	return breakdown
+}
+
+// limitLabel renders a bracket's upper limit, or "And above" for the open
+// top bracket.
+func limitLabel(lang i18n.Lang, limit float64) string {
+	if limit == math.Inf(1) {
+		return i18n.T(lang, "res_and_above")
+	}
+	return i18n.FormatCurrency(lang, limit)
+}
+
+// jsonBracket mirrors a tax breakdown entry with the open top bracket's
+// infinite limit encoded as null, which JSON cannot otherwise represent.
+type jsonBracket struct {
+	Start  float64
+	Limit  *float64
+	Rate   float64
+	Amount float64
+}
+
+// jsonOutput is the calculation as exported to JSON: the output with the
+// months counted and the last of them, as a calendar month.
+func jsonOutput(in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) any {
+	in = normalized(in)
+	breakdown := make([]jsonBracket, len(c.TaxBreakdown))
+	for i, v := range c.TaxBreakdown {
+		breakdown[i] = jsonBracket{Start: v.Start, Rate: v.Rate, Amount: v.Amount}
+		if !math.IsInf(v.Limit, 1) {
+			limit := v.Limit
+			breakdown[i].Limit = &limit
+		}
+	}
+	return struct {
+		pitcalc.CalculatePITOutput
+		TaxBreakdown []jsonBracket
+		Months       int64
+		EndingMonth  int64
+	}{*c, breakdown, in.Months(), in.LastMonth()}
+}
+
+// PlainText renders the calculation as a plain text report.
+func PlainText(in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) string {
+	var b strings.Builder
+	b.WriteString("Myanmar PIT Calculator Report\n==============================\n")
+	b.WriteString(fmt.Sprintf("Months Counted: %s\n", Period(i18n.EN, in)))
+	b.WriteString(fmt.Sprintf("Gross Income (Yearly): %s\n", Currency(c.GrossIncome)))
+	for _, p := range Proration(i18n.EN, c) {
+		b.WriteString(fmt.Sprintf("  %s: %s\n", p.Label, p.Income))
+	}
+	b.WriteString("\nReliefs Breakdown:\n")
+	b.WriteString(fmt.Sprintf("  Basic (20%%, max 10M): %s\n", Currency(c.BasicRelief)))
+	b.WriteString(fmt.Sprintf("  Parents: %s\n", Currency(c.ParentRelief)))
+	b.WriteString(fmt.Sprintf("  Spouse: %s\n", Currency(c.SpouseRelief)))
+	b.WriteString(fmt.Sprintf("  Children: %s\n", Currency(c.ChildRelief)))
+	b.WriteString(fmt.Sprintf("  SSB: %s\n", Currency(c.SSBRelief)))
+	if deps := Dependents(i18n.EN, c); len(deps) > 0 {
+		b.WriteString("\nDependents:\n")
+		for _, d := range deps {
+			b.WriteString(fmt.Sprintf("  %s: %s -> %s\n", d.Label, d.Decision, d.Relief))
+		}
+	}
+	b.WriteString(fmt.Sprintf("\nTotal Taxable Income: %s\n", Currency(c.TotalTexable)))
+	b.WriteString(fmt.Sprintf("Total Reliefs: %s\n", Currency(c.TotalRelief)))
+	b.WriteString(fmt.Sprintf("\nTOTAL TAX: %s\n\n", Currency(c.TotalTax)))
+
+	b.WriteString("Tax Breakdown:\n")
+	for _, v := range c.TaxBreakdown {
+		limitStr := "And above"
+		if v.Limit != math.Inf(1) {
+			limitStr = Currency(v.Limit)
+		}
+		b.WriteString(fmt.Sprintf("  %s to %s -> %s\n", Currency(v.Start), limitStr, Currency(v.Amount)))
+	}
+	return b.String()
+}
+
+func writeCSV(w io.Writer, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	in = normalized(in)
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Metric", "Value (MMK)"})
+	cw.Write([]string{"Months Counted", fmt.Sprint(in.Months())})
+	cw.Write([]string{"Ending Month", i18n.MonthName(i18n.EN, in.LastMonth())})
+	cw.Write([]string{"Gross Income (Yearly)", fmt.Sprintf("%.2f", c.GrossIncome)})
+	for i, p := range Proration(i18n.EN, c) {
+		cw.Write([]string{p.Label, fmt.Sprintf("%.2f", c.Proration[i].Income)})
+	}
+	cw.Write([]string{"Basic Relief", fmt.Sprintf("%.2f", c.BasicRelief)})
+	cw.Write([]string{"Parents Relief", fmt.Sprintf("%.2f", c.ParentRelief)})
+	cw.Write([]string{"Spouse Relief", fmt.Sprintf("%.2f", c.SpouseRelief)})
+	cw.Write([]string{"Children Relief", fmt.Sprintf("%.2f", c.ChildRelief)})
+	cw.Write([]string{"SSB Relief", fmt.Sprintf("%.2f", c.SSBRelief)})
+	for i, d := range Dependents(i18n.EN, c) {
+		cw.Write([]string{d.Label + ": " + d.Decision, fmt.Sprintf("%.2f", c.Dependents[i].Relief)})
+	}
+	cw.Write([]string{"Total Taxable Income", fmt.Sprintf("%.2f", c.TotalTexable)})
+	cw.Write([]string{"Total Reliefs", fmt.Sprintf("%.2f", c.TotalRelief)})
+	cw.Write([]string{"Total Tax", fmt.Sprintf("%.2f", c.TotalTax)})
+	cw.Write([]string{"", ""})
+
+	cw.Write([]string{"Breakdown From", "Breakdown To", "Tax Amount"})
+	for _, tb := range c.TaxBreakdown {
+		limit := fmt.Sprintf("%.2f", tb.Limit)
+		if tb.Limit == math.Inf(1) {
+			limit = "And above"
+		}
+		cw.Write([]string{fmt.Sprintf("%.2f", tb.Start), limit, fmt.Sprintf("%.2f", tb.Amount)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Write renders the calculation in the given format. Localized formats
+// (Markdown, HTML and PDF) use lang for their headings and labels.
+func Write(w io.Writer, f Format, lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	switch f {
+	case FormatJSON:
+		data, err := json.MarshalIndent(jsonOutput(in, c), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+
+	case FormatCSV:
+		return writeCSV(w, in, c)
+
+	case FormatMarkdown:
+		_, err := io.WriteString(w, Markdown(lang, in, c))
+		return err
+
+	case FormatHTML:
+		return writeHTML(w, lang, in, c)
+
+	case FormatPDF:
+		data, err := PDF(lang, in, c)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+
+	case FormatXLSX:
+		data, err := XLSX(in, c)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+
+	case FormatTXT:
+		_, err := io.WriteString(w, PlainText(in, c))
+		return err
+
+	default:
+		return fmt.Errorf("unsupported export format %q", f)
+	}
+}
+
+// WriteFile renders the calculation into the named file. The file is
+// replaced atomically, so a failed export never leaves a truncated report.
+func WriteFile(filename string, f Format, lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, f, lang, in, c); err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(filename, buf.Bytes(), 0644)
+}
@@ -0,0 +1,1018 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+func TestGeneratePDFReport(t *testing.T) {
+	result, err := pitcalc.CalculatePIT(pitcalc.CalculatePITInput{
+		MonthlyIncome:    1000000,
+		StartingMonth:    4,
+		DependentParents: 1,
+		SSB:              72000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, l := range []i18n.Lang{i18n.EN, i18n.MY} {
+		t.Run(string(l), func(t *testing.T) {
+			data, err := PDF(l, pitcalc.CalculatePITInput{StartingMonth: 4}, result)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.HasPrefix(data, []byte("%PDF-")) {
+				t.Errorf("expected PDF header")
+			}
+			if !bytes.Contains(data, []byte("/BaseFont /NotoSansMyanmar-Regular")) {
+				t.Errorf("expected embedded Myanmar font")
+			}
+		})
+	}
+}
+
+func TestGeneratePDFReport_Pages(t *testing.T) {
+	fy, err := pitcalc.ParseFiscalYear("2024-25")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4, FiscalYear: fy}
+	one, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range 40 {
+		input.Dependents = append(input.Dependents, pitcalc.Dependent{
+			Name:         strings.Repeat("Maung ", 10) + strings.Repeat("I", i+1),
+			Relationship: pitcalc.RelationChild,
+			BirthDate:    time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+	}
+	many, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		result   *pitcalc.CalculatePITOutput
+		expected string
+	}{
+		{"no dependents", one, "/Count 1 "},
+		{"many dependents", many, "/Count 3 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := PDF(i18n.EN, input, tt.result)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Contains(data, []byte(tt.expected)) {
+				t.Errorf("expected page count %q", tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateXLSXReport(t *testing.T) {
+	input := pitcalc.CalculatePITInput{
+		MonthlyIncome:    1000000,
+		StartingMonth:    7,
+		DependentParents: 1,
+		Childrens:        2,
+		SSB:              72000,
+	}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := XLSX(input, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sheets := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		sheets[f.Name] = string(b)
+	}
+
+	tests := []struct {
+		sheet   string
+		formula string
+	}{
+		{"xl/worksheets/sheet1.xml", "<f>MOD(B10+8,12)-MOD(B5+8,12)+1</f><v>9</v>"},
+		{"xl/worksheets/sheet1.xml", "<f>MIN(0.2*B13,10000000)</f>"},
+		{"xl/worksheets/sheet1.xml", "<f>B6*1000000</f>"},
+		{"xl/worksheets/sheet1.xml", "<f>MAX(0,B13-B21)</f>"},
+		{"xl/worksheets/sheet1.xml", "<f>&#39;Brackets&#39;!$E$8</f>"},
+		{"xl/worksheets/sheet2.xml", "<f>MAX(0,MIN(&#39;Summary&#39;!$B$23,B3)-B2)</f>"},
+		{"xl/worksheets/sheet2.xml", "<f>MAX(0,&#39;Summary&#39;!$B$23-B6)</f>"},
+		{"xl/worksheets/sheet3.xml", "<f>IF(AND(4&gt;MOD(&#39;Summary&#39;!$B$5+8,12),4&lt;=MOD(&#39;Summary&#39;!$B$10+8,12)+1),1,0)</f><v>1</v>"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(sheets[tt.sheet], tt.formula) {
+			t.Errorf("expected %s to contain %s", tt.sheet, tt.formula)
+		}
+	}
+}
+
+func TestGenerateXLSXReport_EndingMonth(t *testing.T) {
+	input := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4, EndingMonth: 9}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := XLSX(input, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet3.xml" {
+			continue
+		}
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		sheet := string(b)
+		// September is the sixth month of the budget year, on row 7.
+		if !strings.Contains(sheet, `<c r="E7" t="inlineStr"><is><t xml:space="preserve">Final settlement</t>`) {
+			t.Errorf("expected final settlement note for September")
+		}
+		if !strings.Contains(sheet, `<v>6</v>`) {
+			t.Errorf("expected 6 counted months in the schedule total")
+		}
+	}
+}
+
+func TestGenerateXLSXReport_OctoberYear(t *testing.T) {
+	fy, err := pitcalc.ParseFiscalYear("2019-20")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 1, FiscalYear: fy}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := XLSX(input, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sheets := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		sheets[f.Name] = string(b)
+	}
+
+	// January to September is nine months of the October–September year.
+	for _, want := range []string{"<f>MOD(B10+2,12)-MOD(B5+2,12)+1</f><v>9</v>", "FY 2019-20"} {
+		if !strings.Contains(sheets["xl/worksheets/sheet1.xml"], want) {
+			t.Errorf("expected the summary to contain %s", want)
+		}
+	}
+	if !strings.Contains(sheets["xl/worksheets/sheet3.xml"], `<c r="A2" t="inlineStr"><is><t xml:space="preserve">October</t>`) {
+		t.Error("expected the schedule to start in October")
+	}
+}
+
+func TestGenerateXLSXReport_Proration(t *testing.T) {
+	input := pitcalc.CalculatePITInput{
+		MonthlyIncome: 3000000,
+		Joined:        time.Date(2026, time.June, 20, 0, 0, 0, 0, time.UTC),
+		Proration:     pitcalc.ProrateCalendarDays,
+	}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := XLSX(input, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sheets := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		sheets[f.Name] = string(b)
+	}
+
+	// The starting month is taken from the date joined.
+	for _, want := range []string{"Months After Proration", "<f>B4*B14</f>", `<c r="B5"><v>6</v></c>`} {
+		if !strings.Contains(sheets["xl/worksheets/sheet1.xml"], want) {
+			t.Errorf("expected the summary to contain %s", want)
+		}
+	}
+	for _, want := range []string{",11/30,0)</f>", "Prorated, 11 of 30 days"} {
+		if !strings.Contains(sheets["xl/worksheets/sheet3.xml"], want) {
+			t.Errorf("expected the schedule to contain %s", want)
+		}
+	}
+}
+
+func TestGenerateXLSXReport_Dependents(t *testing.T) {
+	fy, err := pitcalc.ParseFiscalYear("2026-27")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := pitcalc.CalculatePITInput{
+		MonthlyIncome: 2000000,
+		StartingMonth: 4,
+		FiscalYear:    fy,
+		Dependents: []pitcalc.Dependent{
+			{Name: "U Ba", Relationship: pitcalc.RelationParent, LivesWith: true},
+			{Name: "Daw Hla", Relationship: pitcalc.RelationParent, LivesWith: true},
+			{Name: "Mg Mg", Relationship: pitcalc.RelationChild, BirthDate: time.Date(2015, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := XLSX(input, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var summary string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			summary = string(b)
+		}
+	}
+
+	// The input cells hold the dependents accepted, so the formulas
+	// recalculate to the reliefs worked out.
+	for _, want := range []string{
+		`<c r="B6"><v>2</v></c>`,
+		`<c r="B8"><v>1</v></c>`,
+		"<f>B6*1000000</f><v>2000000</v>",
+		"<f>B8*500000</f><v>500000</v>",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("expected the summary to contain %s", want)
+		}
+	}
+}
+
+func TestProration(t *testing.T) {
+	result, err := pitcalc.CalculatePIT(pitcalc.CalculatePITInput{
+		MonthlyIncome: 2200000,
+		Joined:        time.Date(2026, time.June, 20, 0, 0, 0, 0, time.UTC),
+		Proration:     pitcalc.ProrateWorkingDays,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		lang   i18n.Lang
+		label  string
+		income string
+	}{
+		{i18n.EN, "June (7 of 22 working days)", "700,000.00 MMK"},
+		{i18n.MY, "ဇွန် (အလုပ်ရက် ၇/၂၂)", "၇၀၀,၀၀၀.၀၀ ကျပ်"},
+	}
+	for _, tt := range tests {
+		got := Proration(tt.lang, result)
+		if len(got) != 1 || got[0].Label != tt.label || got[0].Income != tt.income {
+			t.Errorf("expected %q: %q, got %v", tt.label, tt.income, got)
+		}
+	}
+	if md := Markdown(i18n.EN, pitcalc.CalculatePITInput{}, result); !strings.Contains(md, "  - June (7 of 22 working days): 700,000.00 MMK") {
+		t.Errorf("expected the Markdown report to list the prorated month, got %s", md)
+	}
+}
+
+func TestDependents(t *testing.T) {
+	in := pitcalc.CalculatePITInput{
+		MonthlyIncome: 1000000,
+		StartingMonth: 4,
+		Dependents: []pitcalc.Dependent{
+			{Name: "Daw Mya", Relationship: pitcalc.RelationParent, LivesWith: true},
+			{Relationship: pitcalc.RelationSpouse, HasIncome: true},
+		},
+	}
+	result, err := pitcalc.CalculatePIT(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		lang     i18n.Lang
+		expected []DependentDecision
+	}{
+		{i18n.EN, []DependentDecision{
+			{"Daw Mya (Parent)", "Accepted: lives with the taxpayer", "1,000,000.00 MMK"},
+			{"Spouse", "Rejected: has an income", "0.00 MMK"},
+		}},
+		{i18n.MY, []DependentDecision{
+			{"Daw Mya (မိဘ)", "လက်ခံ: အခွန်ထမ်းနှင့် အတူနေ", "၁,၀၀၀,၀၀၀.၀၀ ကျပ်"},
+			{"အိမ်ထောင်ဖက်", "ပယ်ချ: ဝင်ငွေ ရှိ", "၀.၀၀ ကျပ်"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := Dependents(tt.lang, result); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("expected %v, got %v", tt.expected, got)
+		}
+	}
+	if md := Markdown(i18n.EN, in, result); !strings.Contains(md, "| Daw Mya (Parent) | Accepted: lives with the taxpayer | 1,000,000.00 MMK |") {
+		t.Errorf("expected the Markdown report to list the dependents, got %s", md)
+	}
+	if txt := PlainText(in, result); !strings.Contains(txt, "  Spouse: Rejected: has an income -> 0.00 MMK") {
+		t.Errorf("expected the text report to list the dependents, got %s", txt)
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	calc := func(in pitcalc.CalculatePITInput) error {
+		_, err := pitcalc.CalculatePIT(in)
+		return err
+	}
+	household := func(in pitcalc.HouseholdInput) error {
+		_, err := pitcalc.CalculateHousehold(in)
+		return err
+	}
+	earner := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 4}
+	mother := pitcalc.Dependent{Name: "Daw Mya", Relationship: pitcalc.RelationParent, LivesWith: true}
+	both := pitcalc.HouseholdInput{Spouses: [2]pitcalc.CalculatePITInput{earner, earner}, DependentParents: 1}
+	both.Spouses[0].Dependents = []pitcalc.Dependent{mother}
+	both.Spouses[1].Dependents = []pitcalc.Dependent{mother}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"no income", calc(pitcalc.CalculatePITInput{StartingMonth: 4}), "လစဉ် ဝင်ငွေသည် ၀ ထက် ကြီးရပါမည်"},
+		{"too many parents", calc(pitcalc.CalculatePITInput{MonthlyIncome: 1, StartingMonth: 4, DependentParents: 3}), "မှီခိုသော မိဘ အရေအတွက်သည် ၂ ထက် မပိုရပါ"},
+		{"wrapped proration", calc(pitcalc.CalculatePITInput{MonthlyIncome: 1, StartingMonth: 4, Proration: "daily"}), i18n.T(i18n.MY, "calc_proration")},
+		{"claimed by both", household(both), "Daw Mya ကို ဇနီးမောင်နှံ နှစ်ဦးစလုံးက တောင်းဆိုထားသည်"},
+		{"unknown", io.ErrUnexpectedEOF, "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		if got := ErrorMessage(i18n.MY, tt.err); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	result, err := pitcalc.CalculatePIT(pitcalc.CalculatePITInput{
+		MonthlyIncome: 2200000,
+		Joined:        time.Date(2026, time.June, 20, 0, 0, 0, 0, time.UTC),
+		Proration:     pitcalc.ProrateWorkingDays,
+		Childrens:     1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		lang     i18n.Lang
+		expected []ExplainedStep
+	}{
+		{i18n.EN, []ExplainedStep{
+			{"Months Counted", "June to March", "10"},
+			{"June, employed part of the month", "2,200,000.00 MMK × 7/22 days", "700,000.00 MMK"},
+			{"Gross Income (Yearly)", "2,200,000.00 MMK a month × 9, plus the part months above", "20,500,000.00 MMK"},
+			{"Basic relief", "20% of 20,500,000.00 MMK, at most 10,000,000.00 MMK", "4,100,000.00 MMK"},
+		}},
+		{i18n.MY, []ExplainedStep{
+			{"တွက်ချက်သည့် လအရေအတွက်", "ဇွန် မှ မတ် ထိ", "၁၀"},
+			{"ဇွန် (လမပြည့် အလုပ်လုပ်)", "၂,၂၀၀,၀၀၀.၀၀ ကျပ် × ၇/၂၂ ရက်", "၇၀၀,၀၀၀.၀၀ ကျပ်"},
+			{"နှစ်စဉ် စုစုပေါင်း ဝင်ငွေ", "တစ်လ ၂,၂၀၀,၀၀၀.၀၀ ကျပ် × ၉ နှင့် အထက်ပါ လမပြည့်သော လများ", "၂၀,၅၀၀,၀၀၀.၀၀ ကျပ်"},
+			{"အခြေခံ သက်သာခွင့်", "၂၀% နှုန်း၊ ၂၀,၅၀၀,၀၀၀.၀၀ ကျပ် ပေါ်တွင်၊ အများဆုံး ၁၀,၀၀၀,၀၀၀.၀၀ ကျပ်", "၄,၁၀၀,၀၀၀.၀၀ ကျပ်"},
+		}},
+	}
+	for _, tt := range tests {
+		got := Explain(tt.lang, result)
+		if len(got) != len(result.Explanation) {
+			t.Fatalf("expected %d steps, got %d", len(result.Explanation), len(got))
+		}
+		if !reflect.DeepEqual(got[:4], tt.expected) {
+			t.Errorf("expected %v, got %v", tt.expected, got[:4])
+		}
+	}
+	got := Explain(i18n.EN, result)
+	for _, want := range []ExplainedStep{
+		{"Child relief", "1 × 500,000.00 MMK, for each child under 18, studying or disabled", "500,000.00 MMK"},
+		{"Tax from 10,000,001.00 MMK to 30,000,000.00 MMK", "10% of 5,900,000.00 MMK", "590,000.00 MMK"},
+	} {
+		found := false
+		for _, s := range got {
+			found = found || s == want
+		}
+		if !found {
+			t.Errorf("expected the step %v, got %v", want, got)
+		}
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	fy, err := pitcalc.ParseFiscalYear("2026-27")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	interim, err := pitcalc.ParseFiscalYear("2021-22")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		lang     i18n.Lang
+		in       pitcalc.CalculatePITInput
+		expected string
+	}{
+		{i18n.EN, pitcalc.CalculatePITInput{StartingMonth: 7}, "9 (July – March)"},
+		{i18n.EN, pitcalc.CalculatePITInput{StartingMonth: 7, FiscalYear: fy}, "9 (July – March, FY 2026-27)"},
+		{i18n.MY, pitcalc.CalculatePITInput{StartingMonth: 4, FiscalYear: fy}, "၁၂ (ဧပြီ – မတ်, ၂၀၂၆-၂၇ ဘဏ္ဍာနှစ်)"},
+		{i18n.EN, pitcalc.CalculatePITInput{StartingMonth: 10, FiscalYear: interim}, "6 (October – March, Interim FY 2021-22)"},
+	}
+	for _, tt := range tests {
+		if got := Period(tt.lang, tt.in); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func sampleResult(t *testing.T) (pitcalc.CalculatePITInput, *pitcalc.CalculatePITOutput) {
+	t.Helper()
+	input := pitcalc.CalculatePITInput{
+		MonthlyIncome:    10000000,
+		StartingMonth:    4,
+		DependentParents: 2,
+		DependentSpouse:  1,
+		Childrens:        1,
+		SSB:              72000,
+	}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return input, result
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Format
+		wantErr  bool
+	}{
+		{input: "txt", expected: FormatTXT},
+		{input: ".md", expected: FormatMarkdown},
+		{input: "Markdown", expected: FormatMarkdown},
+		{input: "HTML", expected: FormatHTML},
+		{input: ".htm", expected: FormatHTML},
+		{input: "xlsx", expected: FormatXLSX},
+		{input: "docx", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	input, result := sampleResult(t)
+
+	en := Markdown(i18n.EN, input, result)
+	for _, want := range []string{
+		"- **Months Counted:** 12 (April – March)",
+		"# 🇲🇲 Myanmar PIT Calculator",
+		"## 🛡️  Tax Reliefs",
+		"| Item | Amount |",
+		"| Parents | 2,000,000.00 MMK |",
+		"| **Total Reliefs** |",
+		"| From | To | Tax Amount |",
+		"| 70,000,001.00 MMK | And above |",
+	} {
+		if !strings.Contains(en, want) {
+			t.Errorf("expected English markdown to contain %q", want)
+		}
+	}
+
+	my := Markdown(i18n.MY, input, result)
+	for _, want := range []string{"## 💎 ကျသင့် အခွန်ငွေ", "| မိဘ | ၂,၀၀၀,၀၀၀.၀၀ ကျပ် |", "နှင့်အထက်"} {
+		if !strings.Contains(my, want) {
+			t.Errorf("expected Burmese markdown to contain %q", want)
+		}
+	}
+
+	i18n.SetTraditionalUnits(true)
+	defer i18n.SetTraditionalUnits(false)
+	units := Markdown(i18n.MY, input, result)
+	if want := "| မိဘ | ၂၀ သိန်း |"; !strings.Contains(units, want) {
+		t.Errorf("expected Burmese markdown with traditional units to contain %q", want)
+	}
+	if en := Markdown(i18n.EN, input, result); !strings.Contains(en, "| Parents | 2,000,000.00 MMK |") {
+		t.Error("expected traditional units not to affect English markdown")
+	}
+}
+
+func TestHTML(t *testing.T) {
+	input, result := sampleResult(t)
+
+	out, err := HTML(i18n.MY, input, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`<html lang="my">`,
+		`<meta charset="utf-8">`,
+		"<title>🇲🇲 မြန်မာ ဝင်ငွေခွန် တွက်စက်</title>",
+		`<td>မိဘ</td><td class="amount">၂,၀၀၀,၀၀၀.၀၀ ကျပ်</td>`,
+		"<style>",
+		"၁၂ (ဧပြီ – မတ်)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "<link") || strings.Contains(out, "<script") {
+		t.Errorf("expected a self-contained document without external assets")
+	}
+}
+
+func TestWrite_AllFormats(t *testing.T) {
+	input, result := sampleResult(t)
+
+	for _, f := range Formats() {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, f, i18n.EN, input, result); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.Len() == 0 {
+				t.Errorf("expected output, got none")
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, Format("docx"), i18n.EN, input, result); err == nil {
+		t.Errorf("expected error for unsupported format, got nil")
+	}
+}
+
+func TestWrite_Months(t *testing.T) {
+	input := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 7, EndingMonth: 12}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		format   Format
+		expected []string
+	}{
+		{FormatTXT, []string{"Months Counted: 6 (July – December)\n"}},
+		{FormatCSV, []string{"Months Counted,6\n", "Ending Month,December\n"}},
+		{FormatJSON, []string{`"Months": 6,`, `"EndingMonth": 12`}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, i18n.EN, input, result); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected %q in:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWriteFile_InvalidPath(t *testing.T) {
+	input, result := sampleResult(t)
+	err := WriteFile(t.TempDir()+"/missing/report.md", FormatMarkdown, i18n.EN, input, result)
+	if err == nil {
+		t.Errorf("expected error for invalid path, got nil")
+	}
+}
+
+func TestWrite_JSONTopBracket(t *testing.T) {
+	input, result := sampleResult(t)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, i18n.EN, input, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"Limit": null`) {
+		t.Errorf("expected open top bracket limit to be null, got:\n%s", out)
+	}
+	if strings.Count(out, `"TaxBreakdown"`) != 1 {
+		t.Errorf("expected a single TaxBreakdown field, got:\n%s", out)
+	}
+}
+
+func TestBuiltinPlainTemplateMatchesPlainText(t *testing.T) {
+	sample, _ := sampleResult(t)
+	tests := []struct {
+		name  string
+		input pitcalc.CalculatePITInput
+	}{
+		{"sample", sample},
+		{"prorated", pitcalc.CalculatePITInput{
+			MonthlyIncome: 3000000,
+			Joined:        time.Date(2026, time.June, 20, 0, 0, 0, 0, time.UTC),
+			Proration:     pitcalc.ProrateCalendarDays,
+		}},
+		{"dependents", pitcalc.CalculatePITInput{
+			MonthlyIncome: 2000000,
+			StartingMonth: 4,
+			Dependents: []pitcalc.Dependent{
+				{Name: "Daw Mya", Relationship: pitcalc.RelationParent, LivesWith: true},
+				{Relationship: pitcalc.RelationSpouse, HasIncome: true},
+			},
+		}},
+	}
+
+	tmpl, err := BuiltinTemplate("plain")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tt := range tests {
+		result, err := pitcalc.CalculatePIT(tt.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The TXT report is written in English whatever the language.
+		for _, lang := range []i18n.Lang{i18n.EN, i18n.MY} {
+			t.Run(tt.name+"/"+string(lang), func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := tmpl.Execute(&buf, lang, tt.input, result); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if want := PlainText(tt.input, result); buf.String() != want {
+					t.Errorf("plain template output differs from PlainText:\n%s\n---\n%s", buf.String(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	input, result := sampleResult(t)
+
+	names := BuiltinTemplates()
+	if len(names) == 0 {
+		t.Fatalf("expected built-in templates")
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := ResolveTemplate(name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, l := range []i18n.Lang{i18n.EN, i18n.MY} {
+				var buf bytes.Buffer
+				if err := tmpl.Execute(&buf, l, input, result); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+
+	if _, err := BuiltinTemplate("missing"); err == nil {
+		t.Errorf("expected error for unknown built-in template, got nil")
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	input, result := sampleResult(t)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		file     string
+		src      string
+		lang     i18n.Lang
+		ext      string
+		expected string
+	}{
+		{
+			name:     "text template with helpers",
+			file:     "memo.tmpl",
+			src:      `{{t "res_final_tax"}}={{currency .Output.TotalTax}} ({{percent .Metrics.MarginalRate}})`,
+			lang:     i18n.MY,
+			ext:      ".txt",
+			expected: "💎 ကျသင့် အခွန်ငွေ=၁၈,၅၀၇,၀၀၀.၀၀ ကျပ် (၂၅.၀၀%)",
+		},
+		{
+			name:     "html template escapes values",
+			file:     "memo.html.tmpl",
+			src:      `<p>{{.Lang}} {{"<b>"}} {{number .Input.MonthlyIncome}}</p>`,
+			lang:     i18n.EN,
+			ext:      ".html",
+			expected: "<p>EN &lt;b&gt; 10,000,000.00</p>",
+		},
+		{
+			name:     "markdown template",
+			file:     "memo.md",
+			src:      `{{range .Output.TaxBreakdown}}{{limit .Limit}};{{end}}`,
+			lang:     i18n.EN,
+			ext:      ".md",
+			expected: "2,000,000.00 MMK;10,000,000.00 MMK;30,000,000.00 MMK;50,000,000.00 MMK;70,000,000.00 MMK;And above;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := dir + "/" + tt.file
+			if err := os.WriteFile(path, []byte(tt.src), 0644); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tmpl, err := ResolveTemplate(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tmpl.Ext() != tt.ext {
+				t.Errorf("expected extension %q, got %q", tt.ext, tmpl.Ext())
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, tt.lang, input, result); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+
+	broken := dir + "/broken.tmpl"
+	os.WriteFile(broken, []byte("{{.Output"), 0644)
+	if _, err := LoadTemplate(broken); err == nil {
+		t.Errorf("expected parse error, got nil")
+	}
+	if _, err := LoadTemplate(dir + "/missing.tmpl"); err == nil {
+		t.Errorf("expected error for missing file, got nil")
+	}
+}
+
+func TestNewMetrics(t *testing.T) {
+	input := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, StartingMonth: 10}
+	result, err := pitcalc.CalculatePIT(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := NewMetrics(input, result)
+	if m.Months != 6 {
+		t.Errorf("expected 6 months, got %d", m.Months)
+	}
+	if m.MonthlyTax != result.TotalTax/6 {
+		t.Errorf("expected monthly tax %f, got %f", result.TotalTax/6, m.MonthlyTax)
+	}
+	if m.NetIncome != result.GrossIncome-result.TotalTax {
+		t.Errorf("expected net income %f, got %f", result.GrossIncome-result.TotalTax, m.NetIncome)
+	}
+	if m.MarginalRate != 0.05 {
+		t.Errorf("expected marginal rate 0.05, got %f", m.MarginalRate)
+	}
+
+	// Joined on 20 June, June to March is ten months of FY 2026-27.
+	joined := pitcalc.CalculatePITInput{MonthlyIncome: 1000000, Joined: time.Date(2026, time.June, 20, 0, 0, 0, 0, time.UTC)}
+	result, err = pitcalc.CalculatePIT(joined)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := NewMetrics(joined, result); m.Months != 10 {
+		t.Errorf("expected 10 months, got %d", m.Months)
+	}
+}
+
+func TestExpandFilename(t *testing.T) {
+	at := time.Date(2027, time.February, 3, 14, 5, 9, 0, time.UTC)
+	tests := []struct {
+		name     string
+		pattern  string
+		vars     FilenameVars
+		expected string
+	}{
+		{"default pattern", "PIT_Report_{date}", FilenameVars{Time: at}, "PIT_Report_2027-02-03"},
+		{"all placeholders", "{name}_{fy}_{time}", FilenameVars{Time: at, Name: "Aung Aung"}, "Aung_Aung_2026-27_140509"},
+		{"unsafe name", "PIT_{name}", FilenameVars{Time: at, Name: "../a/b"}, "PIT_.._a_b"},
+		{"empty name trimmed", "PIT_Report_{name}", FilenameVars{Time: at}, "PIT_Report"},
+		{"empty pattern", "", FilenameVars{Time: at}, "PIT_Report"},
+		{"calculation's fiscal year", "PIT_{fy}", FilenameVars{Time: at, Year: pitcalc.FiscalYearOf(time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC))}, "PIT_2018-19"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExpandFilename(tt.pattern, tt.vars)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFiscalYearLabel(t *testing.T) {
+	tests := []struct {
+		at       time.Time
+		expected string
+	}{
+		{time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC), "2025-26"},
+		{time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), "2026-27"},
+		{time.Date(2099, time.December, 1, 0, 0, 0, 0, time.UTC), "2099-00"},
+		{time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC), "2018"},
+		{time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC), "2020-21"},
+	}
+	for _, tt := range tests {
+		if result := FiscalYearLabel(tt.at); result != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, result)
+		}
+	}
+}
+
+func TestWriteFile_ReplacesAtomically(t *testing.T) {
+	input, result := sampleResult(t)
+	dir := t.TempDir()
+	filename := ExportPath(dir, "report", FilenameVars{}, ".txt")
+	os.WriteFile(filename, []byte("old"), 0644)
+
+	if err := WriteFile(filename, FormatTXT, i18n.EN, input, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(filename)
+	if string(data) != PlainText(input, result) {
+		t.Errorf("expected report to replace existing file, got %q", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary files left behind, got %d entries", len(entries))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var scenarios []Scenario
+	for _, sc := range []struct {
+		name    string
+		parents int64
+	}{{"Me", 2}, {"Spouse", 0}} {
+		input := pitcalc.CalculatePITInput{MonthlyIncome: 2000000, StartingMonth: 4, DependentParents: sc.parents}
+		result, err := pitcalc.CalculatePIT(input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		scenarios = append(scenarios, Scenario{Name: sc.name, Input: input, Output: result})
+	}
+
+	rows := Compare(i18n.EN, scenarios)
+	for _, row := range rows {
+		if row.Diffs[0] != 0 {
+			t.Errorf("expected zero diff for the first scenario of %s, got %f", row.ID, row.Diffs[0])
+		}
+		switch row.ID {
+		case "res_parent_relief":
+			if row.Diffs[1] != -2000000 {
+				t.Errorf("expected parent relief diff -2000000, got %f", row.Diffs[1])
+			}
+		case "res_final_tax":
+			if row.Diffs[1] <= 0 {
+				t.Errorf("expected higher tax without parents, got diff %f", row.Diffs[1])
+			}
+		}
+	}
+
+	for _, f := range ComparisonFormats() {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteComparison(&buf, f, i18n.EN, scenarios); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), "Spouse") {
+				t.Errorf("expected scenario names in %s comparison", f)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteComparison(&buf, FormatPDF, i18n.EN, scenarios); err == nil {
+		t.Errorf("expected error for unsupported comparison format, got nil")
+	}
+	if err := WriteComparison(&buf, FormatTXT, i18n.EN, nil); err == nil {
+		t.Errorf("expected error without scenarios, got nil")
+	}
+}
+
+func TestSignedCurrency(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		expected string
+	}{
+		{1000, "+1,000.00 MMK"},
+		{-1000, "-1,000.00 MMK"},
+		{0, "0.00 MMK"},
+	}
+	for _, tt := range tests {
+		if result := SignedCurrency(i18n.EN, tt.amount); result != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, result)
+		}
+	}
+}
+
+func TestAdvice(t *testing.T) {
+	a, err := pitcalc.Advise(
+		pitcalc.CalculatePITInput{MonthlyIncome: 3000000, StartingMonth: 4, SSB: 72000},
+		pitcalc.CalculatePITInput{MonthlyIncome: 500000, StartingMonth: 4, DependentParents: 2, Childrens: 1},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := []string{"A", "B"}
+
+	headers, rows := AdviceTable(i18n.EN, a, names)
+	if len(headers) != 6 || headers[1] != "A" || headers[2] != "B" {
+		t.Errorf("expected the taxpayers as columns, got %q", headers)
+	}
+	// The best three and the claims as entered, ranked sixth.
+	if len(rows) != 4 || rows[3][0] != "6" || rows[3][5] != "as entered" {
+		t.Fatalf("expected 4 rows ending with the claims as entered, got %q", rows)
+	}
+	if rows[2][1] != "1 parent, 1 child" {
+		t.Errorf("expected %q, got %q", "1 parent, 1 child", rows[2][1])
+	}
+
+	_, rows = AdviceTable(i18n.MY, a, names)
+	if rows[0][1] != "မိဘ ၂ ဦး, သားသမီး ၁ ဦး" {
+		t.Errorf("expected %q, got %q", "မိဘ ၂ ဦး, သားသမီး ၁ ဦး", rows[0][1])
+	}
+	notes := AdviceNotes(i18n.MY, a, names)
+	if len(notes) != 5 || !strings.Contains(notes[4], "၀%") {
+		t.Errorf("expected the allocation and two notes for each taxpayer, got %q", notes)
+	}
+}
+
+func TestHouseholdRows(t *testing.T) {
+	out, err := pitcalc.CalculateHousehold(pitcalc.HouseholdInput{
+		Spouses: [2]pitcalc.CalculatePITInput{
+			{MonthlyIncome: 3000000, StartingMonth: 4, DependentParents: 2},
+			{MonthlyIncome: 500000, StartingMonth: 4},
+		},
+		DependentParents: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := HouseholdRows(i18n.EN, out)
+	last := rows[len(rows)-1]
+	if last.ID != "res_final_tax" || len(last.Values) != 3 || last.Values[2] != last.Values[0]+last.Values[1] || last.Values[2] != out.TotalTax {
+		t.Errorf("expected both spouses' tax and their total %f, got %v", out.TotalTax, last.Values)
+	}
+}
@@ -0,0 +1,242 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/myanmar-pit-calculator/pkg/config"
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Metrics are figures derived from a calculation for use in templates.
+type Metrics struct {
+	Months        int64
+	MonthlyTax    float64
+	EffectiveRate float64
+	MarginalRate  float64
+	NetIncome     float64
+}
+
+// NewMetrics derives the template metrics from a calculation.
+func NewMetrics(in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) Metrics {
+	in = normalized(in)
+	m := Metrics{
+		Months:    in.Months(),
+		NetIncome: c.GrossIncome - c.TotalTax,
+	}
+	if m.Months > 0 {
+		m.MonthlyTax = c.TotalTax / float64(m.Months)
+	}
+	if c.GrossIncome > 0 {
+		m.EffectiveRate = c.TotalTax / c.GrossIncome
+	}
+	for _, v := range sortedBreakdown(c) {
+		m.MarginalRate = v.Rate
+	}
+	return m
+}
+
+// TemplateData is the value report templates are executed with. Input is
+// normalized, so its dependent counts and months are those the tax was
+// worked out from.
+type TemplateData struct {
+	Input   pitcalc.CalculatePITInput
+	Output  *pitcalc.CalculatePITOutput
+	Lang    i18n.Lang
+	Metrics Metrics
+}
+
+// Template is a user-defined or built-in report template. Templates whose
+// name ends in .html or .htm (optionally followed by .tmpl) are parsed with
+// html/template so values are escaped; all others use text/template.
+type Template struct {
+	ext  string
+	text *texttemplate.Template
+	html *htmltemplate.Template
+	// lang, when set, is the language the template is always rendered in.
+	lang i18n.Lang
+}
+
+// builtinLangs fixes the language of built-in templates that reproduce a
+// report written only in English.
+var builtinLangs = map[string]i18n.Lang{"plain": i18n.EN}
+
+// templateFuncs returns the functions available to templates. The
+// translation and bracket limit helpers are bound to the report language.
+func templateFuncs(lang i18n.Lang) map[string]any {
+	return map[string]any{
+		"currency": func(v float64) string {
+			return i18n.FormatCurrency(lang, v)
+		},
+		"number": func(v float64) string {
+			return i18n.Number(lang, v, 2)
+		},
+		"percent": func(rate float64) string {
+			return i18n.Digits(lang, fmt.Sprintf("%.2f%%", rate*100))
+		},
+		"t": func(id string) string {
+			return i18n.T(lang, id)
+		},
+		"limit": func(limit float64) string {
+			return limitLabel(lang, limit)
+		},
+		"period": func(in pitcalc.CalculatePITInput) string {
+			return Period(lang, in)
+		},
+		"proration": func(c *pitcalc.CalculatePITOutput) []ProratedMonth {
+			return Proration(lang, c)
+		},
+		"dependents": func(c *pitcalc.CalculatePITOutput) []DependentDecision {
+			return Dependents(lang, c)
+		},
+		"isInf": func(v float64) bool {
+			return math.IsInf(v, 0)
+		},
+	}
+}
+
+// templateExt returns the extension of the file a template produces, e.g.
+// ".html" for "report.html.tmpl" and ".txt" for a template without one.
+func templateExt(name string) string {
+	base := name
+	for _, suffix := range []string{".tmpl", ".tpl", ".gotmpl"} {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	if ext := strings.ToLower(path.Ext(base)); ext != "" {
+		return ext
+	}
+	return ".txt"
+}
+
+func parseTemplate(name, src string) (*Template, error) {
+	t := &Template{ext: templateExt(name)}
+	// Functions are rebound to the report language when executing, so parse
+	// with placeholders for the same names.
+	funcs := templateFuncs(i18n.EN)
+	var err error
+	if t.ext == ".html" || t.ext == ".htm" {
+		t.html, err = htmltemplate.New(name).Funcs(funcs).Parse(src)
+	} else {
+		t.text, err = texttemplate.New(name).Funcs(funcs).Parse(src)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+	return t, nil
+}
+
+// LoadTemplate reads and parses a template file.
+func LoadTemplate(filename string) (*Template, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseTemplate(filepath.Base(filename), string(src))
+}
+
+// BuiltinTemplates lists the names of the templates shipped with the
+// calculator.
+func BuiltinTemplates() []string {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, builtinName(e.Name()))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func builtinName(file string) string {
+	return strings.SplitN(file, ".", 2)[0]
+}
+
+// BuiltinTemplate returns the built-in template with the given name.
+func BuiltinTemplate(name string) (*Template, error) {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	for _, e := range entries {
+		if builtinName(e.Name()) != name {
+			continue
+		}
+		src, err := builtinTemplates.ReadFile("templates/" + e.Name())
+		if err != nil {
+			return nil, err
+		}
+		t, err := parseTemplate(e.Name(), string(src))
+		if err != nil {
+			return nil, err
+		}
+		t.lang = builtinLangs[name]
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown built-in template %q (available: %s)",
+		name, strings.Join(BuiltinTemplates(), ", "))
+}
+
+// ResolveTemplate returns the built-in template with the given name, or
+// loads it from a file otherwise.
+func ResolveTemplate(nameOrPath string) (*Template, error) {
+	for _, name := range BuiltinTemplates() {
+		if name == nameOrPath {
+			return BuiltinTemplate(name)
+		}
+	}
+	return LoadTemplate(nameOrPath)
+}
+
+// Ext returns the extension, including the dot, of the files this template
+// produces.
+func (t *Template) Ext() string {
+	return t.ext
+}
+
+// Execute renders the calculation with the template, in the given language
+// unless the template is always rendered in one.
+func (t *Template) Execute(w io.Writer, lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	if t.lang != "" {
+		lang = t.lang
+	}
+	in = normalized(in)
+	data := TemplateData{
+		Input:   in,
+		Output:  c,
+		Lang:    lang,
+		Metrics: NewMetrics(in, c),
+	}
+	funcs := templateFuncs(lang)
+	if t.html != nil {
+		tmpl, err := t.html.Clone()
+		if err != nil {
+			return err
+		}
+		return tmpl.Funcs(funcs).Execute(w, data)
+	}
+	tmpl, err := t.text.Clone()
+	if err != nil {
+		return err
+	}
+	return tmpl.Funcs(funcs).Execute(w, data)
+}
+
+// WriteFile renders the calculation with the template into the named file,
+// replacing it atomically.
+func (t *Template) WriteFile(filename string, lang i18n.Lang, in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) error {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, lang, in, c); err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(filename, buf.Bytes(), 0644)
+}
@@ -0,0 +1,223 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/myanmar-pit-calculator/pkg/i18n"
+	"github.com/myanmar-pit-calculator/pkg/pitcalc"
+	"github.com/myanmar-pit-calculator/pkg/xlsx"
+)
+
+const (
+	sheetSummary  = "Summary"
+	sheetBrackets = "Brackets"
+	sheetSchedule = "Monthly Schedule"
+)
+
+// Summary sheet rows referenced by formulas on the other sheets.
+const (
+	rowIncome   = 4
+	rowMonth    = 5
+	rowParents  = 6
+	rowSpouse   = 7
+	rowChildren = 8
+	rowSSB      = 9
+	rowEndMonth = 10
+	rowMonths   = 12
+	rowGross    = 13
+	rowProrated = 14
+	rowBasic    = 16
+	rowSSBRel   = 20
+	rowReliefs  = 21
+	rowTaxable  = 23
+	rowTotalTax = 24
+)
+
+// XLSX builds a workbook whose reliefs, taxable income and
+// bracket taxes are formulas over the input cells on the summary sheet, so
+// values can be edited in a spreadsheet application and the tax recomputed.
+// The input cells hold the normalized input, so dependents listed by record
+// and months taken from dates recalculate to the same tax.
+func XLSX(in pitcalc.CalculatePITInput, c *pitcalc.CalculatePITOutput) ([]byte, error) {
+	in = normalized(in)
+	wb := xlsx.New()
+	summary := wb.AddSheet(sheetSummary)
+	brackets := wb.AddSheet(sheetBrackets)
+	schedule := wb.AddSheet(sheetSchedule)
+
+	b := func(row int) string { return xlsx.Ref(2, row) }
+	ref := func(row int) string { return xlsx.AbsRef(sheetSummary, 2, row) }
+	months := in.Months()
+	yearMonths := in.FiscalYear.CalendarMonths()
+	// monthIndex is a formula for a month cell's position in the fiscal
+	// year, from 0 for its first month.
+	monthIndex := func(cell string) string {
+		return fmt.Sprintf("MOD(%s+%d,12)", cell, 12-yearMonths[0])
+	}
+
+	// Summary
+	summary.SetColumnWidth(1, 32)
+	summary.SetColumnWidth(2, 20)
+	summary.SetRow(1, xlsx.Bold("Myanmar PIT Calculator Report"))
+	if !in.FiscalYear.IsZero() {
+		summary.SetRow(2, xlsx.String(in.FiscalYear.Label()))
+	}
+
+	summary.SetRow(3, xlsx.Bold("Inputs"))
+	summary.SetRow(rowIncome, xlsx.String("Monthly Income"), xlsx.Number(in.MonthlyIncome, xlsx.StyleCurrency))
+	summary.SetRow(rowMonth, xlsx.String("Starting Month (1-12)"), xlsx.Number(float64(in.StartingMonth), xlsx.StyleDefault))
+	summary.SetRow(rowParents, xlsx.String("Dependent Parents"), xlsx.Number(float64(in.DependentParents), xlsx.StyleDefault))
+	summary.SetRow(rowSpouse, xlsx.String("Dependent Spouse (0/1)"), xlsx.Number(float64(in.DependentSpouse), xlsx.StyleDefault))
+	summary.SetRow(rowChildren, xlsx.String("Children"), xlsx.Number(float64(in.Childrens), xlsx.StyleDefault))
+	summary.SetRow(rowSSB, xlsx.String("SSB Contribution (Yearly)"), xlsx.Number(in.SSB, xlsx.StyleCurrency))
+	summary.SetRow(rowEndMonth, xlsx.String("Ending Month (1-12)"), xlsx.Number(float64(in.LastMonth()), xlsx.StyleDefault))
+
+	summary.SetRow(11, xlsx.Bold("Income"))
+	summary.SetRow(rowMonths, xlsx.String("Months Counted"),
+		xlsx.Formula(fmt.Sprintf("%s-%s+1", monthIndex(b(rowEndMonth)), monthIndex(b(rowMonth))), float64(months), xlsx.StyleDefault))
+	// With months counted in part, the months are summed from the schedule
+	// and the tax is withheld in proportion to them.
+	prorated := map[int64]pitcalc.ProratedMonth{}
+	for _, p := range c.Proration {
+		prorated[p.Month] = p
+	}
+	countedRow, countedMonths := rowMonths, float64(months)
+	if len(prorated) > 0 {
+		countedRow = rowProrated
+		for _, p := range prorated {
+			countedMonths -= 1 - p.Fraction()
+		}
+		summary.SetRow(rowProrated, xlsx.String("Months After Proration"),
+			xlsx.Formula(fmt.Sprintf("SUM(%s:$B$%d)", xlsx.AbsRef(sheetSchedule, 2, 2), len(yearMonths)+1), countedMonths, xlsx.StyleDefault))
+	}
+	summary.SetRow(rowGross, xlsx.String("Gross Income (Yearly)"),
+		xlsx.Formula(fmt.Sprintf("%s*%s", b(rowIncome), b(countedRow)), c.GrossIncome, xlsx.StyleCurrency))
+
+	summary.SetRow(15, xlsx.Bold("Reliefs"))
+	summary.SetRow(rowBasic, xlsx.String("Basic (20%, max 10M)"),
+		xlsx.Formula(fmt.Sprintf("MIN(%g*%s,%.0f)", pitcalc.BasicReliefRate, b(rowGross), pitcalc.BasicReliefCap), c.BasicRelief, xlsx.StyleCurrency))
+	summary.SetRow(rowBasic+1, xlsx.String("Parents"),
+		xlsx.Formula(fmt.Sprintf("%s*%.0f", b(rowParents), pitcalc.ParentReliefAmount), c.ParentRelief, xlsx.StyleCurrency))
+	summary.SetRow(rowBasic+2, xlsx.String("Spouse"),
+		xlsx.Formula(fmt.Sprintf("%s*%.0f", b(rowSpouse), pitcalc.SpouseReliefAmount), c.SpouseRelief, xlsx.StyleCurrency))
+	summary.SetRow(rowBasic+3, xlsx.String("Children"),
+		xlsx.Formula(fmt.Sprintf("%s*%.0f", b(rowChildren), pitcalc.ChildReliefAmount), c.ChildRelief, xlsx.StyleCurrency))
+	summary.SetRow(rowSSBRel, xlsx.String("SSB"),
+		xlsx.Formula(b(rowSSB), c.SSBRelief, xlsx.StyleCurrency))
+	summary.SetRow(rowReliefs, xlsx.Bold("Total Reliefs"),
+		xlsx.Formula(fmt.Sprintf("SUM(%s:%s)", b(rowBasic), b(rowSSBRel)), c.TotalRelief, xlsx.StyleCurrencyBold))
+
+	// Brackets
+	all := pitcalc.Brackets()
+	brackets.SetColumnWidth(1, 16)
+	brackets.SetColumnWidth(2, 16)
+	brackets.SetColumnWidth(3, 8)
+	brackets.SetColumnWidth(4, 20)
+	brackets.SetColumnWidth(5, 16)
+	brackets.SetRow(1, xlsx.Bold("From"), xlsx.Bold("To"), xlsx.Bold("Rate"), xlsx.Bold("Taxable in Bracket"), xlsx.Bold("Tax Amount"))
+
+	previous := 0.0
+	for i, br := range all {
+		row := i + 2
+		lower := "0"
+		if i > 0 {
+			lower = xlsx.Ref(2, row-1)
+		}
+		slice := math.Max(0, math.Min(c.TotalTexable, br.Limit)-previous)
+		limitCell := xlsx.Number(br.Limit, xlsx.StyleCurrency)
+		formula := fmt.Sprintf("MAX(0,MIN(%s,%s)-%s)", ref(rowTaxable), xlsx.Ref(2, row), lower)
+		if math.IsInf(br.Limit, 1) {
+			limitCell = xlsx.String("And above")
+			formula = fmt.Sprintf("MAX(0,%s-%s)", ref(rowTaxable), lower)
+		}
+		brackets.SetRow(row,
+			xlsx.Number(br.Start, xlsx.StyleCurrency),
+			limitCell,
+			xlsx.Number(br.Rate, xlsx.StylePercent),
+			xlsx.Formula(formula, slice, xlsx.StyleCurrency),
+			xlsx.Formula(fmt.Sprintf("%s*%s", xlsx.Ref(4, row), xlsx.Ref(3, row)), slice*br.Rate, xlsx.StyleCurrency),
+		)
+		previous = br.Limit
+	}
+	first, last := 2, len(all)+1
+	totalRow := last + 1
+	brackets.SetRow(totalRow,
+		xlsx.Bold("Total"), xlsx.String(""), xlsx.String(""),
+		xlsx.Formula(fmt.Sprintf("SUM(%s:%s)", xlsx.Ref(4, first), xlsx.Ref(4, last)), c.TotalTexable, xlsx.StyleCurrencyBold),
+		xlsx.Formula(fmt.Sprintf("SUM(%s:%s)", xlsx.Ref(5, first), xlsx.Ref(5, last)), c.TotalTax, xlsx.StyleCurrencyBold),
+	)
+
+	summary.SetRow(rowTaxable, xlsx.Bold("Total Taxable Income"),
+		xlsx.Formula(fmt.Sprintf("MAX(0,%s-%s)", b(rowGross), b(rowReliefs)), c.TotalTexable, xlsx.StyleCurrencyBold))
+	summary.SetRow(rowTotalTax, xlsx.Bold("Total Tax"),
+		xlsx.Formula(xlsx.AbsRef(sheetBrackets, 5, totalRow), c.TotalTax, xlsx.StyleCurrencyBold))
+	effective := 0.0
+	if c.GrossIncome > 0 {
+		effective = c.TotalTax / c.GrossIncome
+	}
+	summary.SetRow(rowTotalTax+1, xlsx.String("Effective Rate"),
+		xlsx.Formula(fmt.Sprintf("IF(%[1]s>0,%[2]s/%[1]s,0)", b(rowGross), b(rowTotalTax)), effective, xlsx.StylePercent))
+
+	// Monthly Schedule: a month is counted when its position in the fiscal
+	// year lies between the starting and ending months. When the employee
+	// leaves before the year ends, the last counted month is the final
+	// settlement.
+	startIdx, _ := in.FiscalYear.MonthIndex(in.StartingMonth)
+	endIdx, _ := in.FiscalYear.MonthIndex(in.LastMonth())
+	schedule.SetColumnWidth(1, 14)
+	schedule.SetColumnWidth(2, 10)
+	schedule.SetColumnWidth(3, 18)
+	schedule.SetColumnWidth(4, 18)
+	schedule.SetColumnWidth(5, 18)
+	schedule.SetRow(1, xlsx.Bold("Month"), xlsx.Bold("Counted"), xlsx.Bold("Salary"), xlsx.Bold("Tax Withheld"), xlsx.Bold("Note"))
+	for i, month := range yearMonths {
+		row := i + 2
+		share, fraction := "1", 1.0
+		var notes []string
+		if p, ok := prorated[month]; ok {
+			share, fraction = fmt.Sprintf("%d/%d", p.Days, p.DaysInMonth), p.Fraction()
+			days := "days"
+			if p.Basis == pitcalc.ProrateWorkingDays {
+				days = "working days"
+			}
+			notes = append(notes, fmt.Sprintf("Prorated, %d of %d %s", p.Days, p.DaysInMonth, days))
+		}
+		counted := 0.0
+		if int64(i) >= startIdx && int64(i) <= endIdx {
+			counted = fraction
+		}
+		withheld := 0.0
+		if countedMonths > 0 {
+			withheld = counted * c.TotalTax / countedMonths
+		}
+		cells := []xlsx.Cell{
+			xlsx.String(i18n.MonthName(i18n.EN, month)),
+			xlsx.Formula(fmt.Sprintf("IF(AND(%[1]d>%[2]s,%[1]d<=%[3]s+1),%[4]s,0)", i+1, monthIndex(ref(rowMonth)), monthIndex(ref(rowEndMonth)), share), counted, xlsx.StyleDefault),
+			xlsx.Formula(fmt.Sprintf("%s*%s", xlsx.Ref(2, row), ref(rowIncome)), counted*in.MonthlyIncome, xlsx.StyleCurrency),
+			xlsx.Formula(fmt.Sprintf("IF(%[2]s>0,%[1]s*%[3]s/%[2]s,0)", xlsx.Ref(2, row), ref(countedRow), ref(rowTotalTax)), withheld, xlsx.StyleCurrency),
+		}
+		if int64(i) == endIdx && int(endIdx) < len(yearMonths)-1 {
+			notes = append(notes, "Final settlement")
+		}
+		if len(notes) > 0 {
+			cells = append(cells, xlsx.String(strings.Join(notes, "; ")))
+		}
+		schedule.SetRow(row, cells...)
+	}
+	end := len(yearMonths) + 1
+	schedule.SetRow(end+1,
+		xlsx.Bold("Total"),
+		xlsx.Formula(fmt.Sprintf("SUM(B2:B%d)", end), countedMonths, xlsx.StyleDefault),
+		xlsx.Formula(fmt.Sprintf("SUM(C2:C%d)", end), c.GrossIncome, xlsx.StyleCurrencyBold),
+		xlsx.Formula(fmt.Sprintf("SUM(D2:D%d)", end), c.TotalTax, xlsx.StyleCurrencyBold),
+	)
+
+	var buf bytes.Buffer
+	if _, err := wb.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,270 @@
+// Package xlsx implements a minimal Office Open XML workbook writer. It
+// supports inline strings, numbers and formulas with cached values, which is
+// enough to produce spreadsheets that recalculate when a value is edited.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Style selects one of the predefined cell formats.
+type Style int
+
+const (
+	StyleDefault Style = iota
+	StyleBold
+	StyleCurrency
+	StylePercent
+	StyleCurrencyBold
+)
+
+// Cell is a single spreadsheet cell. A cell with a Formula also carries the
+// value it evaluated to at export time so viewers that do not recalculate
+// still show the right figure.
+type Cell struct {
+	Text    string
+	Number  float64
+	Formula string
+	Style   Style
+
+	numeric bool
+}
+
+// String returns a text cell.
+func String(s string) Cell {
+	return Cell{Text: s}
+}
+
+// Bold returns a bold text cell.
+func Bold(s string) Cell {
+	return Cell{Text: s, Style: StyleBold}
+}
+
+// Number returns a numeric cell.
+func Number(v float64, style Style) Cell {
+	return Cell{Number: v, Style: style, numeric: true}
+}
+
+// Formula returns a numeric formula cell with its cached result. The formula
+// is given without the leading '='.
+func Formula(f string, cached float64, style Style) Cell {
+	return Cell{Formula: f, Number: cached, Style: style, numeric: true}
+}
+
+// Sheet is a worksheet within a Workbook.
+type Sheet struct {
+	Name   string
+	rows   map[int][]Cell
+	widths map[int]float64
+	maxRow int
+}
+
+// SetRow sets the cells of a 1-based row starting at column A.
+func (s *Sheet) SetRow(row int, cells ...Cell) {
+	s.rows[row] = cells
+	if row > s.maxRow {
+		s.maxRow = row
+	}
+}
+
+// SetColumnWidth sets the width of a 1-based column in characters.
+func (s *Sheet) SetColumnWidth(col int, width float64) {
+	s.widths[col] = width
+}
+
+// Workbook is a collection of worksheets.
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// New creates an empty workbook.
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet appends a worksheet. Sheet names are limited to 31 characters by
+// spreadsheet applications.
+func (w *Workbook) AddSheet(name string) *Sheet {
+	s := &Sheet{
+		Name:   name,
+		rows:   make(map[int][]Cell),
+		widths: make(map[int]float64),
+	}
+	w.sheets = append(w.sheets, s)
+	return s
+}
+
+// ColumnName converts a 1-based column index to its letter name (1 -> A,
+// 27 -> AA).
+func ColumnName(col int) string {
+	name := ""
+	for col > 0 {
+		col--
+		name = string(rune('A'+col%26)) + name
+		col /= 26
+	}
+	return name
+}
+
+// Ref returns the A1-style reference of a 1-based column and row.
+func Ref(col, row int) string {
+	return ColumnName(col) + strconv.Itoa(row)
+}
+
+// AbsRef returns the absolute ($A$1-style) reference of a cell on the given
+// sheet, quoted so that sheet names containing spaces are valid.
+func AbsRef(sheet string, col, row int) string {
+	return fmt.Sprintf("'%s'!$%s$%d", strings.ReplaceAll(sheet, "'", "''"), ColumnName(col), row)
+}
+
+func escape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func (s *Sheet) xml() []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(s.widths) > 0 {
+		cols := make([]int, 0, len(s.widths))
+		for col := range s.widths {
+			cols = append(cols, col)
+		}
+		sort.Ints(cols)
+		b.WriteString("<cols>")
+		for _, col := range cols {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, col, col, s.widths[col])
+		}
+		b.WriteString("</cols>")
+	}
+	b.WriteString("<sheetData>")
+	for row := 1; row <= s.maxRow; row++ {
+		cells, ok := s.rows[row]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, `<row r="%d">`, row)
+		for i, c := range cells {
+			ref := Ref(i+1, row)
+			style := ""
+			if c.Style != StyleDefault {
+				style = fmt.Sprintf(` s="%d"`, c.Style)
+			}
+			switch {
+			case c.Formula != "":
+				fmt.Fprintf(&b, `<c r="%s"%s><f>%s</f><v>%s</v></c>`,
+					ref, style, escape(c.Formula), strconv.FormatFloat(c.Number, 'f', -1, 64))
+			case c.numeric:
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`,
+					ref, style, strconv.FormatFloat(c.Number, 'f', -1, 64))
+			case c.Text != "":
+				fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`,
+					ref, style, escape(c.Text))
+			}
+		}
+		b.WriteString("</row>")
+	}
+	b.WriteString("</sheetData></worksheet>")
+	return b.Bytes()
+}
+
+// The cellXfs entries are indexed by Style.
+const stylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="9" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// WriteTo serializes the workbook as an .xlsx package.
+func (w *Workbook) WriteTo(out io.Writer) (int64, error) {
+	if len(w.sheets) == 0 {
+		return 0, errors.New("xlsx: workbook has no sheets")
+	}
+
+	var (
+		contentTypes bytes.Buffer
+		workbook     bytes.Buffer
+		rels         bytes.Buffer
+	)
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+
+	rels.WriteString(xml.Header)
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, s := range w.sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(s.Name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	contentTypes.WriteString(`</Types>`)
+	// Ask the application to recalculate all formulas when opening the file.
+	workbook.WriteString(`</sheets><calcPr calcId="0" fullCalcOnLoad="1"/></workbook>`)
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	rels.WriteString(`</Relationships>`)
+
+	parts := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", rels.Bytes()},
+		{"xl/styles.xml", []byte(stylesXML)},
+	}
+	for i, s := range w.sheets {
+		parts = append(parts, struct {
+			name string
+			data []byte
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), s.xml()})
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := f.Write(p.data); err != nil {
+			return 0, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+
+	n, err := out.Write(buf.Bytes())
+	return int64(n), err
+}
@@ -0,0 +1,123 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(b)
+	}
+	return parts
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		col      int
+		expected string
+	}{
+		{1, "A"},
+		{2, "B"},
+		{26, "Z"},
+		{27, "AA"},
+		{52, "AZ"},
+		{703, "AAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := ColumnName(tt.col); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAbsRef(t *testing.T) {
+	if got := AbsRef("Monthly Schedule", 2, 14); got != "'Monthly Schedule'!$B$14" {
+		t.Errorf("unexpected reference %q", got)
+	}
+	if got := AbsRef("Bob's", 1, 1); got != "'Bob''s'!$A$1" {
+		t.Errorf("unexpected reference %q", got)
+	}
+}
+
+func TestWriteTo_NoSheets(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := New().WriteTo(&buf); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestWriteTo_Package(t *testing.T) {
+	wb := New()
+	s := wb.AddSheet("Summary")
+	s.SetColumnWidth(1, 20)
+	s.SetRow(1, Bold("Income"), Number(1000, StyleCurrency))
+	s.SetRow(2, String("Tax <5%>"), Formula("B1*0.05", 50, StyleCurrency))
+	wb.AddSheet("Brackets").SetRow(1, String("Empty"))
+
+	var buf bytes.Buffer
+	if _, err := wb.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parts := readParts(t, buf.Bytes())
+
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+	} {
+		data, ok := parts[name]
+		if !ok {
+			t.Errorf("missing part %s", name)
+			continue
+		}
+		dec := xml.NewDecoder(strings.NewReader(data))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("%s is not well-formed XML: %v", name, err)
+				break
+			}
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Income</t></is></c>`,
+		`<c r="B1" s="2"><v>1000</v></c>`,
+		`Tax &lt;5%&gt;`,
+		`<c r="B2" s="2"><f>B1*0.05</f><v>50</v></c>`,
+		`<col min="1" max="1" width="20" customWidth="1"/>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("expected sheet to contain %s", want)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `fullCalcOnLoad="1"`) {
+		t.Errorf("expected workbook to request recalculation on load")
+	}
+}